@@ -0,0 +1,387 @@
+package diccionario
+
+import "iter"
+
+/* El DiccionarioOrdenado es un árbol binario de búsqueda AVL: después de cada alta o baja se rota lo necesario para
+que, en cada nodo, las alturas de sus dos subárboles difieran a lo sumo en uno. Así la altura del árbol es
+logarítmica en la cantidad de elementos, y también lo son Guardar, Obtener, Borrar, Piso y Techo.
+*/
+
+type abb[K comparable, V any] struct {
+	raiz     *nodoAbb[K, V]
+	cantidad int
+	cmp      func(K, K) int
+
+	// modificaciones cuenta las altas y bajas, que pueden rotar el árbol, como en dictImplementacion
+	modificaciones int
+}
+
+type nodoAbb[K comparable, V any] struct {
+	izquierdo *nodoAbb[K, V]
+	derecho   *nodoAbb[K, V]
+	clave     K
+	dato      V
+	altura    int
+}
+
+// iteradorAbb recorre el árbol inorder con una pila de los nodos cuyo subárbol izquierdo ya se recorrió
+type iteradorAbb[K comparable, V any] struct {
+	diccionario    *abb[K, V]
+	pila           []*nodoAbb[K, V]
+	desde          *K
+	hasta          *K
+	modificaciones int
+}
+
+// CrearABB crea un DiccionarioOrdenado que compara las claves con la función cmp, que debe devolver un número
+// negativo si la primera clave es menor, cero si son iguales y positivo si la primera es mayor (como cmp.Compare)
+func CrearABB[K comparable, V any](cmp func(K, K) int) DiccionarioOrdenado[K, V] {
+	return &abb[K, V]{cmp: cmp}
+}
+
+// ######################################### BALANCEO ######################################################
+
+func (nodo *nodoAbb[K, V]) alturaDe() int {
+	if nodo == nil {
+		return 0
+	}
+	return nodo.altura
+}
+
+func (nodo *nodoAbb[K, V]) actualizarAltura() {
+	nodo.altura = 1 + max(nodo.izquierdo.alturaDe(), nodo.derecho.alturaDe())
+}
+
+func (nodo *nodoAbb[K, V]) factorBalance() int {
+	return nodo.izquierdo.alturaDe() - nodo.derecho.alturaDe()
+}
+
+func rotarDerecha[K comparable, V any](nodo *nodoAbb[K, V]) *nodoAbb[K, V] {
+	nuevaRaiz := nodo.izquierdo
+	nodo.izquierdo = nuevaRaiz.derecho
+	nuevaRaiz.derecho = nodo
+	nodo.actualizarAltura()
+	nuevaRaiz.actualizarAltura()
+	return nuevaRaiz
+}
+
+func rotarIzquierda[K comparable, V any](nodo *nodoAbb[K, V]) *nodoAbb[K, V] {
+	nuevaRaiz := nodo.derecho
+	nodo.derecho = nuevaRaiz.izquierdo
+	nuevaRaiz.izquierdo = nodo
+	nodo.actualizarAltura()
+	nuevaRaiz.actualizarAltura()
+	return nuevaRaiz
+}
+
+// balancear recalcula la altura del nodo y lo rota si quedó desbalanceado. Devuelve la nueva raíz del subárbol
+func balancear[K comparable, V any](nodo *nodoAbb[K, V]) *nodoAbb[K, V] {
+	nodo.actualizarAltura()
+	switch balance := nodo.factorBalance(); {
+	case balance > 1:
+		if nodo.izquierdo.factorBalance() < 0 {
+			nodo.izquierdo = rotarIzquierda(nodo.izquierdo)
+		}
+		return rotarDerecha(nodo)
+	case balance < -1:
+		if nodo.derecho.factorBalance() > 0 {
+			nodo.derecho = rotarDerecha(nodo.derecho)
+		}
+		return rotarIzquierda(nodo)
+	}
+	return nodo
+}
+
+// ###################################### ALTAS Y BAJAS ####################################################
+
+func (arbol *abb[K, V]) guardar(nodo *nodoAbb[K, V], clave K, dato V) *nodoAbb[K, V] {
+	if nodo == nil {
+		arbol.cantidad++
+		arbol.modificaciones++
+		return &nodoAbb[K, V]{clave: clave, dato: dato, altura: 1}
+	}
+	switch comparacion := arbol.cmp(clave, nodo.clave); {
+	case comparacion < 0:
+		nodo.izquierdo = arbol.guardar(nodo.izquierdo, clave, dato)
+	case comparacion > 0:
+		nodo.derecho = arbol.guardar(nodo.derecho, clave, dato)
+	default:
+		nodo.dato = dato
+		return nodo
+	}
+	return balancear(nodo)
+}
+
+// borrar saca la clave del subárbol y devuelve la nueva raíz del subárbol y el nodo borrado, o nil si la clave no
+// estaba
+func (arbol *abb[K, V]) borrar(nodo *nodoAbb[K, V], clave K) (*nodoAbb[K, V], *nodoAbb[K, V]) {
+	if nodo == nil {
+		return nil, nil
+	}
+	var borrado *nodoAbb[K, V]
+	switch comparacion := arbol.cmp(clave, nodo.clave); {
+	case comparacion < 0:
+		nodo.izquierdo, borrado = arbol.borrar(nodo.izquierdo, clave)
+	case comparacion > 0:
+		nodo.derecho, borrado = arbol.borrar(nodo.derecho, clave)
+	default:
+		if nodo.izquierdo == nil {
+			return nodo.derecho, nodo
+		}
+		if nodo.derecho == nil {
+			return nodo.izquierdo, nodo
+		}
+		// Con dos hijos, el nodo pasa a ocupar el lugar de su sucesor inorder, que se saca del subárbol derecho
+		var sucesor *nodoAbb[K, V]
+		nodo.derecho, sucesor = borrarMinimo(nodo.derecho)
+		sucesor.izquierdo, sucesor.derecho = nodo.izquierdo, nodo.derecho
+		return balancear(sucesor), nodo
+	}
+	if borrado == nil {
+		return nodo, nil
+	}
+	return balancear(nodo), borrado
+}
+
+// borrarMinimo saca el menor nodo del subárbol y devuelve la nueva raíz del subárbol y el nodo sacado
+func borrarMinimo[K comparable, V any](nodo *nodoAbb[K, V]) (*nodoAbb[K, V], *nodoAbb[K, V]) {
+	if nodo.izquierdo == nil {
+		return nodo.derecho, nodo
+	}
+	var minimo *nodoAbb[K, V]
+	nodo.izquierdo, minimo = borrarMinimo(nodo.izquierdo)
+	return balancear(nodo), minimo
+}
+
+func (arbol *abb[K, V]) buscar(clave K) *nodoAbb[K, V] {
+	nodo := arbol.raiz
+	for nodo != nil {
+		switch comparacion := arbol.cmp(clave, nodo.clave); {
+		case comparacion < 0:
+			nodo = nodo.izquierdo
+		case comparacion > 0:
+			nodo = nodo.derecho
+		default:
+			return nodo
+		}
+	}
+	return nil
+}
+
+// ################################### PRIMITIVAS DICCIONARIO #################################################
+
+func (arbol *abb[K, V]) Guardar(clave K, dato V) {
+	arbol.raiz = arbol.guardar(arbol.raiz, clave, dato)
+}
+
+func (arbol *abb[K, V]) Pertenece(clave K) bool {
+	return arbol.buscar(clave) != nil
+}
+
+func (arbol *abb[K, V]) Obtener(clave K) V {
+	dato, ok := arbol.ObtenerOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (arbol *abb[K, V]) ObtenerOk(clave K) (V, bool) {
+	nodo := arbol.buscar(clave)
+	if nodo == nil {
+		var cero V
+		return cero, false
+	}
+	return nodo.dato, true
+}
+
+func (arbol *abb[K, V]) Borrar(clave K) V {
+	dato, ok := arbol.BorrarOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (arbol *abb[K, V]) BorrarOk(clave K) (V, bool) {
+	var borrado *nodoAbb[K, V]
+	arbol.raiz, borrado = arbol.borrar(arbol.raiz, clave)
+	if borrado == nil {
+		var cero V
+		return cero, false
+	}
+	arbol.cantidad--
+	arbol.modificaciones++
+	return borrado.dato, true
+}
+
+func (arbol *abb[K, V]) Cantidad() int {
+	return arbol.cantidad
+}
+
+// ################################### PRIMITIVAS ORDENADAS ##################################################
+
+func (arbol *abb[K, V]) Minimo() (K, V) {
+	if arbol.raiz == nil {
+		panic(ErrDiccionarioVacio.Error())
+	}
+	nodo := arbol.raiz
+	for nodo.izquierdo != nil {
+		nodo = nodo.izquierdo
+	}
+	return nodo.clave, nodo.dato
+}
+
+func (arbol *abb[K, V]) Maximo() (K, V) {
+	if arbol.raiz == nil {
+		panic(ErrDiccionarioVacio.Error())
+	}
+	nodo := arbol.raiz
+	for nodo.derecho != nil {
+		nodo = nodo.derecho
+	}
+	return nodo.clave, nodo.dato
+}
+
+func (arbol *abb[K, V]) Piso(clave K) (K, V) {
+	var piso *nodoAbb[K, V]
+	for nodo := arbol.raiz; nodo != nil; {
+		comparacion := arbol.cmp(clave, nodo.clave)
+		if comparacion == 0 {
+			return nodo.clave, nodo.dato
+		}
+		if comparacion < 0 {
+			nodo = nodo.izquierdo
+		} else {
+			piso, nodo = nodo, nodo.derecho
+		}
+	}
+	if piso == nil {
+		panic(ErrSinPiso.Error())
+	}
+	return piso.clave, piso.dato
+}
+
+func (arbol *abb[K, V]) Techo(clave K) (K, V) {
+	var techo *nodoAbb[K, V]
+	for nodo := arbol.raiz; nodo != nil; {
+		comparacion := arbol.cmp(clave, nodo.clave)
+		if comparacion == 0 {
+			return nodo.clave, nodo.dato
+		}
+		if comparacion > 0 {
+			nodo = nodo.derecho
+		} else {
+			techo, nodo = nodo, nodo.izquierdo
+		}
+	}
+	if techo == nil {
+		panic(ErrSinTecho.Error())
+	}
+	return techo.clave, techo.dato
+}
+
+// ###################################### ITERADOR INTERNO ###################################################
+
+// Iterar, como IterarRango, entra en pánico si la función visitar agrega o borra claves del diccionario
+func (arbol *abb[K, V]) Iterar(visitar func(K, V) bool) {
+	arbol.IterarRango(nil, nil, visitar)
+}
+
+func (arbol *abb[K, V]) IterarRango(desde *K, hasta *K, visitar func(K, V) bool) {
+	arbol.iterarRango(arbol.raiz, desde, hasta, visitar, arbol.modificaciones)
+}
+
+// iterarRango recorre inorder el subárbol, salteando las ramas que quedan fuera del rango. Devuelve false si hay
+// que dejar de iterar
+func (arbol *abb[K, V]) iterarRango(nodo *nodoAbb[K, V], desde *K, hasta *K, visitar func(K, V) bool, modificaciones int) bool {
+	if nodo == nil {
+		return true
+	}
+	mayorADesde := desde == nil || arbol.cmp(nodo.clave, *desde) >= 0
+	menorAHasta := hasta == nil || arbol.cmp(nodo.clave, *hasta) <= 0
+	if mayorADesde && !arbol.iterarRango(nodo.izquierdo, desde, hasta, visitar, modificaciones) {
+		return false
+	}
+	if mayorADesde && menorAHasta {
+		if !visitar(nodo.clave, nodo.dato) {
+			return false
+		}
+		if arbol.modificaciones != modificaciones {
+			panic(ErrDiccionarioModificado.Error())
+		}
+	}
+	return !menorAHasta || arbol.iterarRango(nodo.derecho, desde, hasta, visitar, modificaciones)
+}
+
+func (arbol *abb[K, V]) All() iter.Seq2[K, V] {
+	return arbol.Iterar
+}
+
+func (arbol *abb[K, V]) Claves() iter.Seq[K] {
+	return func(visitar func(K) bool) {
+		arbol.Iterar(func(clave K, _ V) bool { return visitar(clave) })
+	}
+}
+
+func (arbol *abb[K, V]) Valores() iter.Seq[V] {
+	return func(visitar func(V) bool) {
+		arbol.Iterar(func(_ K, dato V) bool { return visitar(dato) })
+	}
+}
+
+// ################################### PRIMITIVAS ITERADOR ###################################################
+
+func (arbol *abb[K, V]) Iterador() IterDiccionario[K, V] {
+	return arbol.IteradorRango(nil, nil)
+}
+
+func (arbol *abb[K, V]) IteradorRango(desde *K, hasta *K) IterDiccionario[K, V] {
+	iter := &iteradorAbb[K, V]{diccionario: arbol, desde: desde, hasta: hasta, modificaciones: arbol.modificaciones}
+	iter.apilarIzquierdos(arbol.raiz)
+	return iter
+}
+
+// apilarIzquierdos apila el nodo y sus descendientes izquierdos, salteando los que son menores a desde (y con ellos
+// sus subárboles izquierdos)
+func (iter *iteradorAbb[K, V]) apilarIzquierdos(nodo *nodoAbb[K, V]) {
+	for nodo != nil {
+		if iter.desde != nil && iter.diccionario.cmp(nodo.clave, *iter.desde) < 0 {
+			nodo = nodo.derecho
+			continue
+		}
+		iter.pila = append(iter.pila, nodo)
+		nodo = nodo.izquierdo
+	}
+}
+
+// HaySiguiente, y con ella el resto de las primitivas, entra en pánico si el diccionario cambió desde que se creó
+// el iterador
+func (iter *iteradorAbb[K, V]) HaySiguiente() bool {
+	if iter.modificaciones != iter.diccionario.modificaciones {
+		panic(ErrDiccionarioModificado.Error())
+	}
+	if len(iter.pila) == 0 {
+		return false
+	}
+	tope := iter.pila[len(iter.pila)-1]
+	return iter.hasta == nil || iter.diccionario.cmp(tope.clave, *iter.hasta) <= 0
+}
+
+func (iter *iteradorAbb[K, V]) VerActual() (K, V) {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	tope := iter.pila[len(iter.pila)-1]
+	return tope.clave, tope.dato
+}
+
+func (iter *iteradorAbb[K, V]) Siguiente() K {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	tope := iter.pila[len(iter.pila)-1]
+	iter.pila = iter.pila[:len(iter.pila)-1]
+	iter.apilarIzquierdos(tope.derecho)
+	return tope.clave
+}
@@ -0,0 +1,141 @@
+package diccionario_test
+
+import (
+	"cmp"
+	TDADiccionario "diccionario"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// crearABBConClaves crea un ABB de enteros con las claves indicadas, cada una con el doble como dato
+func crearABBConClaves(claves ...int) TDADiccionario.DiccionarioOrdenado[int, int] {
+	abb := TDADiccionario.CrearABB[int, int](cmp.Compare[int])
+	for _, clave := range claves {
+		abb.Guardar(clave, 2*clave)
+	}
+	return abb
+}
+
+// rango recorre el rango con IterarRango y con IteradorRango, verifica que ambos den las mismas claves y las devuelve
+func rango(t *testing.T, abb TDADiccionario.DiccionarioOrdenado[int, int], desde *int, hasta *int) []int {
+	var interno, externo []int
+	abb.IterarRango(desde, hasta, func(clave int, dato int) bool {
+		require.EqualValues(t, 2*clave, dato)
+		interno = append(interno, clave)
+		return true
+	})
+	for iter := abb.IteradorRango(desde, hasta); iter.HaySiguiente(); {
+		externo = append(externo, iter.Siguiente())
+	}
+	require.Equal(t, interno, externo)
+	return interno
+}
+
+func TestABBRecorreEnOrden(t *testing.T) {
+	t.Log("Guardando claves en orden aleatorio, el ABB las recorre de menor a mayor, y sigue haciéndolo después de " +
+		"borrar la mitad")
+	claves := rand.Perm(1000)
+	abb := crearABBConClaves(claves...)
+	slices.Sort(claves)
+	require.Equal(t, claves, slices.Collect(abb.Claves()))
+	require.Equal(t, claves, rango(t, abb, nil, nil))
+	for i := 0; i < 1000; i += 2 {
+		require.EqualValues(t, 2*i, abb.Borrar(i))
+	}
+	var impares []int
+	for i := 1; i < 1000; i += 2 {
+		impares = append(impares, i)
+	}
+	require.Equal(t, impares, rango(t, abb, nil, nil))
+	require.EqualValues(t, 500, abb.Cantidad())
+}
+
+func TestABBRangos(t *testing.T) {
+	t.Log("IterarRango e IteradorRango incluyen ambos extremos, aceptan extremos que no son claves del árbol, y " +
+		"un extremo nil deja el rango abierto de ese lado")
+	abb := crearABBConClaves(50, 20, 80, 10, 30, 70, 90, 25, 35)
+	desde, hasta := 25, 70
+	require.Equal(t, []int{25, 30, 35, 50, 70}, rango(t, abb, &desde, &hasta))
+	desde, hasta = 21, 69
+	require.Equal(t, []int{25, 30, 35, 50}, rango(t, abb, &desde, &hasta))
+	require.Equal(t, []int{10, 20, 25, 30, 35, 50}, rango(t, abb, nil, &hasta))
+	require.Equal(t, []int{25, 30, 35, 50, 70, 80, 90}, rango(t, abb, &desde, nil))
+	desde, hasta = 91, 100
+	require.Empty(t, rango(t, abb, &desde, &hasta))
+	desde, hasta = 60, 40
+	require.Empty(t, rango(t, abb, &desde, &hasta))
+}
+
+func TestABBIterarRangoCorta(t *testing.T) {
+	t.Log("IterarRango deja de iterar cuando la función devuelve false")
+	abb := crearABBConClaves(rand.Perm(100)...)
+	desde := 10
+	var vistas []int
+	abb.IterarRango(&desde, nil, func(clave int, _ int) bool {
+		vistas = append(vistas, clave)
+		return len(vistas) < 5
+	})
+	require.Equal(t, []int{10, 11, 12, 13, 14}, vistas)
+}
+
+func TestABBMinimoMaximoPisoTecho(t *testing.T) {
+	t.Log("Minimo, Maximo, Piso y Techo devuelven la clave correcta y su dato, y entran en pánico si no hay ninguna")
+	abb := crearABBConClaves()
+	require.PanicsWithValue(t, "El diccionario esta vacio", func() { abb.Minimo() })
+	require.PanicsWithValue(t, "El diccionario esta vacio", func() { abb.Maximo() })
+	require.PanicsWithValue(t, TDADiccionario.ErrSinPiso.Error(), func() { abb.Piso(5) })
+	require.PanicsWithValue(t, TDADiccionario.ErrSinTecho.Error(), func() { abb.Techo(5) })
+
+	abb = crearABBConClaves(40, 10, 30, 20)
+	clave, dato := abb.Minimo()
+	require.EqualValues(t, 10, clave)
+	require.EqualValues(t, 20, dato)
+	clave, _ = abb.Maximo()
+	require.EqualValues(t, 40, clave)
+	clave, dato = abb.Piso(25)
+	require.EqualValues(t, 20, clave)
+	require.EqualValues(t, 40, dato)
+	clave, _ = abb.Piso(30)
+	require.EqualValues(t, 30, clave)
+	clave, _ = abb.Techo(25)
+	require.EqualValues(t, 30, clave)
+	clave, _ = abb.Techo(10)
+	require.EqualValues(t, 10, clave)
+	clave, _ = abb.Piso(100)
+	require.EqualValues(t, 40, clave)
+	require.PanicsWithValue(t, TDADiccionario.ErrSinPiso.Error(), func() { abb.Piso(9) })
+	require.PanicsWithValue(t, TDADiccionario.ErrSinTecho.Error(), func() { abb.Techo(41) })
+}
+
+func TestABBClavesOrdenadasNoDegeneran(t *testing.T) {
+	t.Log("Guardar y borrar muchas claves ya ordenadas, el peor caso de un ABB sin balancear, no degenera el árbol")
+	abb := TDADiccionario.CrearABB[int, int](cmp.Compare[int])
+	for i := 0; i < 200000; i++ {
+		abb.Guardar(i, i)
+	}
+	for i := 0; i < 200000; i++ {
+		require.EqualValues(t, i, abb.Obtener(i))
+	}
+	for i := 199999; i >= 0; i-- {
+		abb.Borrar(i)
+	}
+	require.EqualValues(t, 0, abb.Cantidad())
+}
+
+func TestABBIteradorFallaSiSeModifica(t *testing.T) {
+	t.Log("Los iteradores del ABB entran en pánico si se agregan o borran claves mientras recorren")
+	abb := crearABBConClaves(1, 2, 3)
+	iter := abb.Iterador()
+	abb.Guardar(1, 5)
+	require.True(t, iter.HaySiguiente())
+	abb.Guardar(4, 8)
+	require.PanicsWithValue(t, TDADiccionario.ErrDiccionarioModificado.Error(), func() { iter.Siguiente() })
+	require.PanicsWithValue(t, TDADiccionario.ErrDiccionarioModificado.Error(), func() {
+		abb.Iterar(func(clave int, _ int) bool {
+			abb.Borrar(clave)
+			return true
+		})
+	})
+}
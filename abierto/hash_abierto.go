@@ -0,0 +1,319 @@
+package abierto
+
+import (
+	"bytes"
+	TDADiccionario "diccionario"
+	"hash/maphash"
+	"iter"
+)
+
+const (
+	CAPACIDAD_INICIAL  = 127
+	MAX_FC             = 0.9
+	MIN_FC             = 0.1
+	FACTOR_REDIMENSION = 2
+)
+
+type dictImplementacion[K comparable, V any] struct {
+	tablaValores []*listaEnlazada[*elementoTabla[K, V]]
+	elementos    int
+	hasher       TDADiccionario.Hasher[K]
+	semilla      maphash.Seed
+
+	// agrandamientos y achicamientos cuentan las redimensiones, para Estadisticas
+	agrandamientos int
+	achicamientos  int
+}
+
+type elementoTabla[K comparable, V any] struct {
+	clave K
+	valor V
+}
+
+type iteradorDict[K comparable, V any] struct {
+	diccionario         *dictImplementacion[K, V]
+	iteradorListaActual *iterListaEnlazada[*elementoTabla[K, V]]
+	posicionListaActual int
+}
+
+func crearTabla[K comparable, V any](capacidad int) []*listaEnlazada[*elementoTabla[K, V]] {
+	tabla := make([]*listaEnlazada[*elementoTabla[K, V]], capacidad)
+	for i := range tabla {
+		tabla[i] = crearListaEnlazada[*elementoTabla[K, V]]()
+	}
+	return tabla
+}
+
+// CrearHashAbierto crea un Diccionario implementado como una tabla de hash abierta: cada posición de la tabla es una
+// lista enlazada con todos los elementos cuya clave cae en esa posición
+func CrearHashAbierto[K comparable, V any]() TDADiccionario.Diccionario[K, V] {
+	dict := new(dictImplementacion[K, V])
+	dict.tablaValores = crearTabla[K, V](CAPACIDAD_INICIAL)
+	dict.hasher = TDADiccionario.HasherPorDefecto[K]()
+	dict.semilla = maphash.MakeSeed()
+	return dict
+}
+
+func (dict *dictImplementacion[K, V]) posicionEnTabla(clave K, largo int) int {
+	return int(dict.hasher.Hashear(clave, TDADiccionario.PRIMER_HASH, dict.semilla) % uint64(largo))
+}
+
+func esPrimo(n int) bool {
+	if n <= 1 {
+		return false
+	}
+	for i := 2; i*i <= n; i++ {
+		if n%i == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func proximoPrimo(n int) int {
+	for !esPrimo(n) {
+		n++
+	}
+	return n
+}
+
+func anteriorPrimo(n int) int {
+	for !esPrimo(n) {
+		n--
+	}
+	return n
+}
+
+// buscar devuelve un iterador de la lista posicionado en el elemento con la clave, o nil si la clave no está
+func (dict *dictImplementacion[K, V]) buscar(lista *listaEnlazada[*elementoTabla[K, V]], clave K) *iterListaEnlazada[*elementoTabla[K, V]] {
+	//Este es un iterador de las listas del dict
+	for iter := lista.Iterador(); iter.HaySiguiente(); iter.Siguiente() {
+		if iter.VerActual().clave == clave {
+			return iter
+		}
+	}
+	return nil
+}
+
+func (dict *dictImplementacion[K, V]) redimensionar(nuevaCapacidad int) {
+	if nuevaCapacidad > len(dict.tablaValores) {
+		dict.agrandamientos++
+	} else {
+		dict.achicamientos++
+	}
+	nuevaTabla := crearTabla[K, V](nuevaCapacidad)
+
+	for _, lista := range dict.tablaValores {
+		for iter := lista.Iterador(); iter.HaySiguiente(); {
+			elemento := iter.Siguiente()
+			nuevaTabla[dict.posicionEnTabla(elemento.clave, nuevaCapacidad)].InsertarUltimo(elemento)
+		}
+	}
+
+	dict.tablaValores = nuevaTabla
+}
+
+// Guardar guarda el par clave-dato en el Diccionario. Si la clave ya se encontraba, se actualiza el dato asociado
+func (dict *dictImplementacion[K, V]) Guardar(clave K, dato V) {
+	lista := dict.tablaValores[dict.posicionEnTabla(clave, len(dict.tablaValores))]
+	if iter := dict.buscar(lista, clave); iter != nil {
+		iter.VerActual().valor = dato
+		return
+	}
+
+	// La clave es nueva: recién ahora se sabe que la carga aumenta
+	if float32(dict.elementos+1)/float32(len(dict.tablaValores)) > MAX_FC {
+		dict.redimensionar(proximoPrimo(len(dict.tablaValores) * FACTOR_REDIMENSION))
+		lista = dict.tablaValores[dict.posicionEnTabla(clave, len(dict.tablaValores))]
+	}
+	lista.InsertarUltimo(&elementoTabla[K, V]{clave, dato})
+	dict.elementos++
+}
+
+// Pertenece determina si una clave ya se encuentra en el diccionario, o no
+func (dict *dictImplementacion[K, V]) Pertenece(clave K) bool {
+	_, ok := dict.ObtenerOk(clave)
+	return ok
+}
+
+// Obtener devuelve el dato asociado a una clave. Si la clave no pertenece, debe entrar en pánico con mensaje
+// 'La clave no pertenece al diccionario'
+func (dict *dictImplementacion[K, V]) Obtener(clave K) V {
+	dato, ok := dict.ObtenerOk(clave)
+	if !ok {
+		panic(TDADiccionario.ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+// ObtenerOk devuelve el dato asociado a una clave y true, o el valor cero de V y false si la clave no pertenece
+func (dict *dictImplementacion[K, V]) ObtenerOk(clave K) (V, bool) {
+	lista := dict.tablaValores[dict.posicionEnTabla(clave, len(dict.tablaValores))]
+	if iter := dict.buscar(lista, clave); iter != nil {
+		return iter.VerActual().valor, true
+	}
+	var cero V
+	return cero, false
+}
+
+// Borrar borra del Diccionario la clave indicada, devolviendo el dato que se encontraba asociado. Si la clave no
+// pertenece al diccionario, debe entrar en pánico con un mensaje 'La clave no pertenece al diccionario'
+func (dict *dictImplementacion[K, V]) Borrar(clave K) V {
+	dato, ok := dict.BorrarOk(clave)
+	if !ok {
+		panic(TDADiccionario.ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+// BorrarOk borra la clave indicada y devuelve el dato asociado y true, o el valor cero de V y false si la clave no
+// pertenece
+func (dict *dictImplementacion[K, V]) BorrarOk(clave K) (V, bool) {
+	lista := dict.tablaValores[dict.posicionEnTabla(clave, len(dict.tablaValores))]
+	iter := dict.buscar(lista, clave)
+	if iter == nil {
+		var cero V
+		return cero, false
+	}
+
+	borrado := iter.Borrar()
+	dict.elementos--
+
+	if float32(dict.elementos)/float32(len(dict.tablaValores)) < MIN_FC {
+		if nuevaCapacidad := anteriorPrimo(len(dict.tablaValores) / FACTOR_REDIMENSION); nuevaCapacidad >= CAPACIDAD_INICIAL {
+			dict.redimensionar(nuevaCapacidad)
+		}
+	}
+	return borrado.valor, true
+}
+
+// Cantidad devuelve la cantidad de elementos dentro del diccionario
+func (dict *dictImplementacion[K, V]) Cantidad() int {
+	return dict.elementos
+}
+
+func (dict *dictImplementacion[K, V]) Iterar(visitar func(K, V) bool) {
+	for _, lista := range dict.tablaValores {
+		for iter := lista.Iterador(); iter.HaySiguiente(); iter.Siguiente() {
+			if !visitar(iter.VerActual().clave, iter.VerActual().valor) {
+				return
+			}
+		}
+	}
+}
+
+func (dict *dictImplementacion[K, V]) All() iter.Seq2[K, V] {
+	return dict.Iterar
+}
+
+func (dict *dictImplementacion[K, V]) Claves() iter.Seq[K] {
+	return func(visitar func(K) bool) {
+		dict.Iterar(func(clave K, _ V) bool { return visitar(clave) })
+	}
+}
+
+func (dict *dictImplementacion[K, V]) Valores() iter.Seq[V] {
+	return func(visitar func(V) bool) {
+		dict.Iterar(func(_ K, dato V) bool { return visitar(dato) })
+	}
+}
+
+func (dict *dictImplementacion[K, V]) Iterador() TDADiccionario.IterDiccionario[K, V] {
+	iter := &iteradorDict[K, V]{diccionario: dict}
+	iter.avanzarLista(0)
+	return iter
+}
+
+// MarshalBinary genera los mismos datos que el Diccionario de CrearHash, con los codecs de CodecPorDefecto
+func (dict *dictImplementacion[K, V]) MarshalBinary() ([]byte, error) {
+	return TDADiccionario.SerializarBinario[K, V](dict, nil, nil)
+}
+
+// UnmarshalBinary reemplaza el contenido del diccionario por el de los datos. La tabla se crea desde el principio
+// con lugar para todos los elementos, así la carga no redimensiona
+func (dict *dictImplementacion[K, V]) UnmarshalBinary(datos []byte) error {
+	pares, cantidad, err := TDADiccionario.DecodificarBinario[K, V](datos, nil, nil)
+	if err != nil {
+		return err
+	}
+	dict.reservar(cantidad)
+	for clave, dato := range pares {
+		dict.Guardar(clave, dato)
+	}
+	return nil
+}
+
+// reservar vacía el diccionario y le crea una tabla con lugar para la cantidad de elementos indicada
+func (dict *dictImplementacion[K, V]) reservar(cantidad int) {
+	capacidad := CAPACIDAD_INICIAL
+	for float32(cantidad)/float32(capacidad) > MAX_FC {
+		capacidad = proximoPrimo(capacidad * FACTOR_REDIMENSION)
+	}
+	dict.tablaValores = crearTabla[K, V](capacidad)
+	dict.elementos = 0
+}
+
+// Estadisticas informa la capacidad, la carga y las redimensiones de la tabla. Los demás contadores son de los
+// diccionarios de TDADiccionario.CrearHashCon y quedan en cero
+func (dict *dictImplementacion[K, V]) Estadisticas() TDADiccionario.EstadisticasHash {
+	return TDADiccionario.EstadisticasHash{
+		Capacidad:      len(dict.tablaValores),
+		Elementos:      dict.elementos,
+		FactorDeCarga:  float64(dict.elementos) / float64(len(dict.tablaValores)),
+		Agrandamientos: dict.agrandamientos,
+		Achicamientos:  dict.achicamientos,
+	}
+}
+
+func (dict *dictImplementacion[K, V]) ReiniciarEstadisticas() {
+	dict.agrandamientos, dict.achicamientos = 0, 0
+}
+
+// MarshalJSON escribe los pares como un objeto JSON, como el Diccionario de CrearHash
+func (dict *dictImplementacion[K, V]) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	err := TDADiccionario.CodificarJSON[K, V](&buffer, dict, false)
+	return buffer.Bytes(), err
+}
+
+func (dict *dictImplementacion[K, V]) UnmarshalJSON(datos []byte) error {
+	return TDADiccionario.DecodificarJSON[K, V](bytes.NewReader(datos), dict)
+}
+
+// #############  Primitivas ITERADOR EXTERNO >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+// avanzarLista posiciona al iterador en la primera lista no vacía a partir de la posición indicada
+func (iter *iteradorDict[K, V]) avanzarLista(desde int) {
+	tabla := iter.diccionario.tablaValores
+	iter.posicionListaActual = desde
+	for iter.posicionListaActual < len(tabla) && tabla[iter.posicionListaActual].EstaVacia() {
+		iter.posicionListaActual++
+	}
+	if iter.posicionListaActual < len(tabla) {
+		iter.iteradorListaActual = tabla[iter.posicionListaActual].Iterador()
+	}
+}
+
+func (iter *iteradorDict[K, V]) HaySiguiente() bool {
+	return iter.posicionListaActual < len(iter.diccionario.tablaValores)
+}
+
+func (iter *iteradorDict[K, V]) VerActual() (K, V) {
+	if !iter.HaySiguiente() {
+		panic(TDADiccionario.ErrIteradorTerminado.Error())
+	}
+	actual := iter.iteradorListaActual.VerActual()
+	return actual.clave, actual.valor
+}
+
+func (iter *iteradorDict[K, V]) Siguiente() K {
+	if !iter.HaySiguiente() {
+		panic(TDADiccionario.ErrIteradorTerminado.Error())
+	}
+
+	actual := iter.iteradorListaActual.Siguiente()
+	if !iter.iteradorListaActual.HaySiguiente() {
+		iter.avanzarLista(iter.posicionListaActual + 1)
+	}
+	return actual.clave
+}
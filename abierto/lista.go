@@ -0,0 +1,82 @@
+package abierto
+
+// listaEnlazada es la lista simplemente enlazada que usa cada posición de la tabla del hash abierto
+type listaEnlazada[T any] struct {
+	primero *nodoLista[T]
+	ultimo  *nodoLista[T]
+	largo   int
+}
+
+type nodoLista[T any] struct {
+	dato      T
+	siguiente *nodoLista[T]
+}
+
+type iterListaEnlazada[T any] struct {
+	lista    *listaEnlazada[T]
+	anterior *nodoLista[T]
+	actual   *nodoLista[T]
+}
+
+func crearListaEnlazada[T any]() *listaEnlazada[T] {
+	return new(listaEnlazada[T])
+}
+
+func (lista *listaEnlazada[T]) EstaVacia() bool {
+	return lista.largo == 0
+}
+
+func (lista *listaEnlazada[T]) InsertarUltimo(dato T) {
+	nodo := &nodoLista[T]{dato: dato}
+	if lista.EstaVacia() {
+		lista.primero = nodo
+	} else {
+		lista.ultimo.siguiente = nodo
+	}
+	lista.ultimo = nodo
+	lista.largo++
+}
+
+func (lista *listaEnlazada[T]) Iterador() *iterListaEnlazada[T] {
+	return &iterListaEnlazada[T]{lista: lista, actual: lista.primero}
+}
+
+func (iter *iterListaEnlazada[T]) HaySiguiente() bool {
+	return iter.actual != nil
+}
+
+func (iter *iterListaEnlazada[T]) VerActual() T {
+	if !iter.HaySiguiente() {
+		panic("El iterador termino de iterar")
+	}
+	return iter.actual.dato
+}
+
+func (iter *iterListaEnlazada[T]) Siguiente() T {
+	if !iter.HaySiguiente() {
+		panic("El iterador termino de iterar")
+	}
+	dato := iter.actual.dato
+	iter.anterior = iter.actual
+	iter.actual = iter.actual.siguiente
+	return dato
+}
+
+// Borrar elimina de la lista el elemento actual, dejando al iterador en el siguiente
+func (iter *iterListaEnlazada[T]) Borrar() T {
+	if !iter.HaySiguiente() {
+		panic("El iterador termino de iterar")
+	}
+	borrado := iter.actual
+	if iter.anterior == nil {
+		iter.lista.primero = borrado.siguiente
+	} else {
+		iter.anterior.siguiente = borrado.siguiente
+	}
+	if borrado == iter.lista.ultimo {
+		iter.lista.ultimo = iter.anterior
+	}
+	iter.actual = borrado.siguiente
+	iter.lista.largo--
+	return borrado.dato
+}
@@ -0,0 +1,141 @@
+package diccionario
+
+import "iter"
+
+// PoliticaDatosRepetidos elige qué hace un DiccionarioBidireccional al guardar un dato que ya está asociado a otra
+// clave
+type PoliticaDatosRepetidos int
+
+const (
+	// ENTRAR_EN_PANICO hace que Guardar entre en pánico con un mensaje 'El dato ya esta asociado a otra clave'
+	ENTRAR_EN_PANICO PoliticaDatosRepetidos = iota
+
+	// REEMPLAZAR borra la clave que tenía el dato antes de guardarlo con la nueva
+	REEMPLAZAR
+
+	// RECHAZAR hace que Guardar no haga nada
+	RECHAZAR
+)
+
+/* El diccionario bidireccional mantiene dos Diccionarios de hash, uno de claves a datos y otro de datos a claves,
+siempre con los mismos pares.
+*/
+
+type dictBidireccional[K comparable, V comparable] struct {
+	directo  Diccionario[K, V]
+	inverso  Diccionario[V, K]
+	politica PoliticaDatosRepetidos
+}
+
+func CrearHashBidireccional[K comparable, V comparable]() DiccionarioBidireccional[K, V] {
+	return CrearHashBidireccionalCon[K, V](ENTRAR_EN_PANICO)
+}
+
+func CrearHashBidireccionalCon[K comparable, V comparable](politica PoliticaDatosRepetidos) DiccionarioBidireccional[K, V] {
+	return &dictBidireccional[K, V]{
+		directo:  CrearHash[K, V](),
+		inverso:  CrearHash[V, K](),
+		politica: politica,
+	}
+}
+
+func (dict *dictBidireccional[K, V]) Guardar(clave K, dato V) {
+	if otraClave, ok := dict.inverso.ObtenerOk(dato); ok {
+		if otraClave == clave {
+			return
+		}
+		switch dict.politica {
+		case ENTRAR_EN_PANICO:
+			panic(ErrDatoRepetido.Error())
+		case RECHAZAR:
+			return
+		}
+		dict.directo.Borrar(otraClave)
+	}
+	if datoAnterior, ok := dict.directo.ObtenerOk(clave); ok {
+		dict.inverso.Borrar(datoAnterior)
+	}
+	dict.directo.Guardar(clave, dato)
+	dict.inverso.Guardar(dato, clave)
+}
+
+func (dict *dictBidireccional[K, V]) Pertenece(clave K) bool {
+	return dict.directo.Pertenece(clave)
+}
+
+func (dict *dictBidireccional[K, V]) Obtener(clave K) V {
+	return dict.directo.Obtener(clave)
+}
+
+func (dict *dictBidireccional[K, V]) ObtenerOk(clave K) (V, bool) {
+	return dict.directo.ObtenerOk(clave)
+}
+
+func (dict *dictBidireccional[K, V]) Borrar(clave K) V {
+	dato, ok := dict.BorrarOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (dict *dictBidireccional[K, V]) BorrarOk(clave K) (V, bool) {
+	dato, ok := dict.directo.BorrarOk(clave)
+	if ok {
+		dict.inverso.Borrar(dato)
+	}
+	return dato, ok
+}
+
+func (dict *dictBidireccional[K, V]) Cantidad() int {
+	return dict.directo.Cantidad()
+}
+
+// ##################################### BÚSQUEDA POR DATO ###################################################
+
+func (dict *dictBidireccional[K, V]) PerteneceDato(dato V) bool {
+	return dict.inverso.Pertenece(dato)
+}
+
+func (dict *dictBidireccional[K, V]) ObtenerClave(dato V) K {
+	clave, ok := dict.inverso.ObtenerOk(dato)
+	if !ok {
+		panic(ErrDatoNoPertenece.Error())
+	}
+	return clave
+}
+
+func (dict *dictBidireccional[K, V]) ObtenerClaveOk(dato V) (K, bool) {
+	return dict.inverso.ObtenerOk(dato)
+}
+
+func (dict *dictBidireccional[K, V]) BorrarPorDato(dato V) K {
+	clave, ok := dict.inverso.BorrarOk(dato)
+	if !ok {
+		panic(ErrDatoNoPertenece.Error())
+	}
+	dict.directo.Borrar(clave)
+	return clave
+}
+
+// ###################################### RECORRIDOS #########################################################
+
+func (dict *dictBidireccional[K, V]) Iterar(visitar func(K, V) bool) {
+	dict.directo.Iterar(visitar)
+}
+
+func (dict *dictBidireccional[K, V]) All() iter.Seq2[K, V] {
+	return dict.directo.All()
+}
+
+func (dict *dictBidireccional[K, V]) Claves() iter.Seq[K] {
+	return dict.directo.Claves()
+}
+
+func (dict *dictBidireccional[K, V]) Valores() iter.Seq[V] {
+	return dict.directo.Valores()
+}
+
+func (dict *dictBidireccional[K, V]) Iterador() IterDiccionario[K, V] {
+	return dict.directo.Iterador()
+}
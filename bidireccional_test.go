@@ -0,0 +1,93 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBidireccionalBuscarEnAmbosSentidos(t *testing.T) {
+	t.Log("Se puede obtener el dato de una clave y la clave de un dato, y ambos sentidos se mantienen al reemplazar " +
+		"y al borrar")
+	dic := TDADiccionario.CrearHashBidireccional[int, string]()
+	dic.Guardar(1, "uno")
+	dic.Guardar(2, "dos")
+	require.EqualValues(t, "uno", dic.Obtener(1))
+	require.EqualValues(t, 2, dic.ObtenerClave("dos"))
+	require.True(t, dic.PerteneceDato("uno"))
+
+	dic.Guardar(1, "one")
+	require.False(t, dic.PerteneceDato("uno"))
+	require.EqualValues(t, 1, dic.ObtenerClave("one"))
+	require.EqualValues(t, 2, dic.Cantidad())
+
+	require.EqualValues(t, "dos", dic.Borrar(2))
+	require.False(t, dic.PerteneceDato("dos"))
+	require.EqualValues(t, 1, dic.BorrarPorDato("one"))
+	require.False(t, dic.Pertenece(1))
+	require.EqualValues(t, 0, dic.Cantidad())
+
+	_, ok := dic.ObtenerClaveOk("one")
+	require.False(t, ok)
+	require.PanicsWithValue(t, "El dato no pertenece al diccionario", func() { dic.ObtenerClave("one") })
+	require.PanicsWithValue(t, "El dato no pertenece al diccionario", func() { dic.BorrarPorDato("one") })
+	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Borrar(1) })
+}
+
+func TestBidireccionalDatoRepetidoEntraEnPanico(t *testing.T) {
+	t.Log("Por defecto, guardar un dato que ya tiene otra clave entra en pánico sin modificar el diccionario, y " +
+		"volver a guardar el mismo par no hace nada")
+	dic := TDADiccionario.CrearHashBidireccional[int, string]()
+	dic.Guardar(1, "uno")
+	dic.Guardar(1, "uno")
+	require.PanicsWithValue(t, "El dato ya esta asociado a otra clave", func() { dic.Guardar(2, "uno") })
+	require.False(t, dic.Pertenece(2))
+	require.EqualValues(t, 1, dic.ObtenerClave("uno"))
+	require.EqualValues(t, 1, dic.Cantidad())
+}
+
+func TestBidireccionalDatoRepetidoReemplaza(t *testing.T) {
+	t.Log("Con REEMPLAZAR, guardar un dato que ya tiene otra clave borra esa clave, y libera el dato anterior de " +
+		"la clave nueva")
+	dic := TDADiccionario.CrearHashBidireccionalCon[int, string](TDADiccionario.REEMPLAZAR)
+	dic.Guardar(1, "uno")
+	dic.Guardar(2, "dos")
+	dic.Guardar(2, "uno")
+	require.False(t, dic.Pertenece(1))
+	require.False(t, dic.PerteneceDato("dos"))
+	require.EqualValues(t, 2, dic.ObtenerClave("uno"))
+	require.EqualValues(t, 1, dic.Cantidad())
+}
+
+func TestBidireccionalDatoRepetidoSeRechaza(t *testing.T) {
+	t.Log("Con RECHAZAR, guardar un dato que ya tiene otra clave no hace nada")
+	dic := TDADiccionario.CrearHashBidireccionalCon[int, string](TDADiccionario.RECHAZAR)
+	dic.Guardar(1, "uno")
+	dic.Guardar(2, "dos")
+	dic.Guardar(2, "uno")
+	require.EqualValues(t, "dos", dic.Obtener(2))
+	require.EqualValues(t, 1, dic.ObtenerClave("uno"))
+	require.EqualValues(t, 2, dic.Cantidad())
+}
+
+func TestBidireccionalVolumen(t *testing.T) {
+	t.Log("Con muchos pares, los dos sentidos siguen teniendo los mismos pares después de reemplazos y borrados. " +
+		"Cada clave par le quita su dato a la impar siguiente")
+	dic := TDADiccionario.CrearHashBidireccionalCon[int, int](TDADiccionario.REEMPLAZAR)
+	for i := 0; i < 10000; i++ {
+		dic.Guardar(i, -i)
+	}
+	for i := 0; i < 10000; i += 2 {
+		dic.Guardar(i, -i-1)
+	}
+	for i := 0; i < 10000; i += 3 {
+		dic.BorrarOk(i)
+	}
+	for clave, dato := range dic.All() {
+		require.EqualValues(t, clave, dic.ObtenerClave(dato))
+	}
+	for i := 0; i < 10000; i++ {
+		require.Equal(t, i%2 == 0 && i%3 != 0, dic.Pertenece(i), "clave %d", i)
+	}
+	require.EqualValues(t, 3333, dic.Cantidad())
+}
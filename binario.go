@@ -0,0 +1,437 @@
+package diccionario
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"hash/crc32"
+	"iter"
+)
+
+const (
+	FIRMA_BINARIO   = "TDAD"
+	VERSION_BINARIO = 1
+	LARGO_CRC       = 4
+)
+
+/* Formato de MarshalBinary, versión 1:
+
+	FIRMA_BINARIO | VERSION_BINARIO (1 byte) | cantidad de pares (uvarint)
+	por cada par: largo de la clave (uvarint) | clave | largo del dato (uvarint) | dato
+	CRC-32 IEEE de todo lo anterior (4 bytes, little endian)
+
+Claves y datos se codifican con los Codec de las Opciones del diccionario.
+
+Implementan encoding.BinaryMarshaler y encoding.BinaryUnmarshaler los diccionarios de CrearHashCon,
+CrearHashConcurrenteCon, CrearHashPorInsercionCon y CrearABB, y los de los paquetes abierto y cerrado. Los que no tienen
+Opciones usan CodecPorDefecto. Los demás, como CacheLRU, DiccionarioConExpiracion, DiccionarioPersistente o
+DiccionarioBidireccional, no los implementan: cada uno tiene estado que el formato no guarda (el orden de uso, los
+vencimientos, el archivo, la política de datos repetidos). Si alcanza con sus pares, se pueden serializar con
+SerializarBinario.
+*/
+
+// CodecString guarda los bytes del string tal cual
+type CodecString[T ~string] struct{}
+
+// CodecEntero guarda los 8 bytes de la representación del entero, sin importar su ancho
+type CodecEntero[T Entero] struct{}
+
+// CodecGob codifica cada dato por separado con encoding/gob. Sirve para cualquier tipo que gob sepa codificar, pero
+// repite la descripción del tipo en cada dato, por lo que solo se usa cuando no hay un codec específico para el tipo
+type CodecGob[T any] struct{}
+
+func (CodecString[T]) Codificar(destino []byte, dato T) ([]byte, error) {
+	return append(destino, dato...), nil
+}
+
+func (CodecString[T]) Decodificar(origen []byte) (T, error) {
+	return T(origen), nil
+}
+
+func (CodecEntero[T]) Codificar(destino []byte, dato T) ([]byte, error) {
+	return binary.LittleEndian.AppendUint64(destino, uint64(dato)), nil
+}
+
+func (CodecEntero[T]) Decodificar(origen []byte) (T, error) {
+	if len(origen) != 8 {
+		return 0, fmt.Errorf("%w: un entero ocupa 8 bytes, no %d", ErrFormatoInvalido, len(origen))
+	}
+	return T(binary.LittleEndian.Uint64(origen)), nil
+}
+
+func (CodecGob[T]) Codificar(destino []byte, dato T) ([]byte, error) {
+	buffer := bytes.NewBuffer(destino)
+	if err := gob.NewEncoder(buffer).Encode(&dato); err != nil {
+		return destino, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (CodecGob[T]) Decodificar(origen []byte) (T, error) {
+	var dato T
+	err := gob.NewDecoder(bytes.NewReader(origen)).Decode(&dato)
+	return dato, err
+}
+
+// CodecPorDefecto elige el codec que corresponda al tipo, o CodecGob si no hay ninguno específico. Como
+// HasherPorDefecto, solo reconoce los tipos predeclarados
+func CodecPorDefecto[T any]() Codec[T] {
+	var codec any
+	switch any(*new(T)).(type) {
+	case string:
+		codec = CodecString[string]{}
+	case int:
+		codec = CodecEntero[int]{}
+	case int8:
+		codec = CodecEntero[int8]{}
+	case int16:
+		codec = CodecEntero[int16]{}
+	case int32:
+		codec = CodecEntero[int32]{}
+	case int64:
+		codec = CodecEntero[int64]{}
+	case uint:
+		codec = CodecEntero[uint]{}
+	case uint8:
+		codec = CodecEntero[uint8]{}
+	case uint16:
+		codec = CodecEntero[uint16]{}
+	case uint32:
+		codec = CodecEntero[uint32]{}
+	case uint64:
+		codec = CodecEntero[uint64]{}
+	case uintptr:
+		codec = CodecEntero[uintptr]{}
+	default:
+		return CodecGob[T]{}
+	}
+	return codec.(Codec[T])
+}
+
+// CrearHashDesdeBinario crea un Diccionario con las opciones indicadas y le carga los datos generados por
+// MarshalBinary
+func CrearHashDesdeBinario[K comparable, V any](opciones Opciones[K, V], datos []byte) (Diccionario[K, V], error) {
+	dict := CrearHashCon(opciones)
+	if err := dict.(interface{ UnmarshalBinary([]byte) error }).UnmarshalBinary(datos); err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+// SerializarBinario genera, para cualquier Diccionario, los mismos datos que MarshalBinary. Si un codec es nil se
+// usa el de CodecPorDefecto
+func SerializarBinario[K comparable, V any](dict Diccionario[K, V], codecClaves Codec[K], codecDatos Codec[V]) ([]byte, error) {
+	codecs := crearCodecs(Opciones[K, V]{CodecClaves: codecClaves, CodecDatos: codecDatos})
+	return codecs.serializar(dict.Cantidad(), dict.Iterar)
+}
+
+// DeserializarBinario reemplaza el contenido de cualquier Diccionario por el de los datos generados por
+// MarshalBinary o SerializarBinario, como UnmarshalBinary. Si los datos son inválidos el diccionario no cambia. A
+// diferencia de UnmarshalBinary, borra las claves de a una y no puede reservar lugar antes de cargar los pares
+func DeserializarBinario[K comparable, V any](datos []byte, dict Diccionario[K, V], codecClaves Codec[K], codecDatos Codec[V]) error {
+	codecs := crearCodecs(Opciones[K, V]{CodecClaves: codecClaves, CodecDatos: codecDatos})
+	pares, err := codecs.deserializar(datos)
+	if err != nil {
+		return err
+	}
+	reemplazarPares(dict, pares)
+	return nil
+}
+
+// DecodificarBinario lee los datos generados por MarshalBinary o SerializarBinario y devuelve sus pares, en el orden
+// en que aparecen, y su cantidad. Sirve para implementar UnmarshalBinary en diccionarios de otros paquetes que
+// puedan crear su tabla con lugar para todos los pares antes de guardarlos. Si un codec es nil se usa el de
+// CodecPorDefecto
+func DecodificarBinario[K comparable, V any](datos []byte, codecClaves Codec[K], codecDatos Codec[V]) (iter.Seq2[K, V], int, error) {
+	codecs := crearCodecs(Opciones[K, V]{CodecClaves: codecClaves, CodecDatos: codecDatos})
+	pares, err := codecs.deserializar(datos)
+	if err != nil {
+		return nil, 0, err
+	}
+	return func(visitar func(K, V) bool) {
+		for _, par := range pares {
+			if !visitar(par.clave, par.dato) {
+				return
+			}
+		}
+	}, len(pares), nil
+}
+
+// reservable lo implementan las tablas de hash de este paquete: reservar las vacía y les crea una tabla con lugar
+// para la cantidad de elementos indicada, así la carga de UnmarshalBinary no redimensiona
+type reservable interface {
+	reservar(cantidad int)
+}
+
+// reemplazarPares borra las claves del diccionario y guarda los pares en orden. Como no conoce la tabla del
+// diccionario, la carga puede achicarla y volver a agrandarla
+func reemplazarPares[K comparable, V any](dict Diccionario[K, V], pares []parClaveValor[K, V]) {
+	claves := make([]K, 0, dict.Cantidad())
+	dict.Iterar(func(clave K, _ V) bool {
+		claves = append(claves, clave)
+		return true
+	})
+	for _, clave := range claves {
+		dict.BorrarOk(clave)
+	}
+	for _, par := range pares {
+		dict.Guardar(par.clave, par.dato)
+	}
+}
+
+// ###################################### CODIFICACIÓN ######################################################
+
+type codecsDiccionario[K comparable, V any] struct {
+	claves Codec[K]
+	datos  Codec[V]
+}
+
+func crearCodecs[K comparable, V any](opciones Opciones[K, V]) codecsDiccionario[K, V] {
+	codecs := codecsDiccionario[K, V]{claves: opciones.CodecClaves, datos: opciones.CodecDatos}
+	if codecs.claves == nil {
+		codecs.claves = CodecPorDefecto[K]()
+	}
+	if codecs.datos == nil {
+		codecs.datos = CodecPorDefecto[V]()
+	}
+	return codecs
+}
+
+// codificarConLargo agrega lo que genera codificar precedido por su largo
+func codificarConLargo[T any](destino []byte, codec Codec[T], dato T, buffer []byte) ([]byte, []byte, error) {
+	buffer, err := codec.Codificar(buffer[:0], dato)
+	if err != nil {
+		return destino, buffer, err
+	}
+	destino = binary.AppendUvarint(destino, uint64(len(buffer)))
+	return append(destino, buffer...), buffer, nil
+}
+
+func (codecs codecsDiccionario[K, V]) serializar(cantidad int, iterar func(func(K, V) bool)) ([]byte, error) {
+	datos := append([]byte(FIRMA_BINARIO), VERSION_BINARIO)
+	datos = binary.AppendUvarint(datos, uint64(cantidad))
+	var buffer []byte
+	var err error
+	iterar(func(clave K, dato V) bool {
+		if datos, buffer, err = codificarConLargo(datos, codecs.claves, clave, buffer); err != nil {
+			return false
+		}
+		datos, buffer, err = codificarConLargo(datos, codecs.datos, dato, buffer)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return binary.LittleEndian.AppendUint32(datos, crc32.ChecksumIEEE(datos)), nil
+}
+
+// ##################################### DECODIFICACIÓN #####################################################
+
+// leerConLargo lee un largo y los bytes que le siguen, y devuelve los bytes y el resto
+func leerConLargo(datos []byte) ([]byte, []byte, error) {
+	largo, leidos := binary.Uvarint(datos)
+	if leidos <= 0 || largo > uint64(len(datos)-leidos) {
+		return nil, nil, ErrFormatoInvalido
+	}
+	fin := leidos + int(largo)
+	return datos[leidos:fin], datos[fin:], nil
+}
+
+// deserializar decodifica todos los pares antes de devolverlos, así un error deja al diccionario sin cambios
+func (codecs codecsDiccionario[K, V]) deserializar(datos []byte) ([]parClaveValor[K, V], error) {
+	if len(datos) < len(FIRMA_BINARIO)+1+LARGO_CRC || string(datos[:len(FIRMA_BINARIO)]) != FIRMA_BINARIO {
+		return nil, ErrFormatoInvalido
+	}
+	if version := datos[len(FIRMA_BINARIO)]; version != VERSION_BINARIO {
+		return nil, fmt.Errorf("%w: %d", ErrVersionNoSoportada, version)
+	}
+	contenido, crc := datos[:len(datos)-LARGO_CRC], datos[len(datos)-LARGO_CRC:]
+	if crc32.ChecksumIEEE(contenido) != binary.LittleEndian.Uint32(crc) {
+		return nil, ErrDatosCorruptos
+	}
+
+	resto := contenido[len(FIRMA_BINARIO)+1:]
+	cantidad, leidos := binary.Uvarint(resto)
+	// Cada par ocupa al menos los dos bytes de sus largos
+	if leidos <= 0 || cantidad > uint64(len(resto)/2) {
+		return nil, ErrFormatoInvalido
+	}
+	resto = resto[leidos:]
+	pares := make([]parClaveValor[K, V], cantidad)
+	for i := range pares {
+		var clave, dato []byte
+		var err error
+		if clave, resto, err = leerConLargo(resto); err != nil {
+			return nil, err
+		}
+		if dato, resto, err = leerConLargo(resto); err != nil {
+			return nil, err
+		}
+		if pares[i].clave, err = codecs.claves.Decodificar(clave); err != nil {
+			return nil, fmt.Errorf("%w: clave %d: %w", ErrFormatoInvalido, i, err)
+		}
+		if pares[i].dato, err = codecs.datos.Decodificar(dato); err != nil {
+			return nil, fmt.Errorf("%w: dato %d: %w", ErrFormatoInvalido, i, err)
+		}
+	}
+	if len(resto) != 0 {
+		return nil, ErrFormatoInvalido
+	}
+	return pares, nil
+}
+
+// ####################################### CUCKOO ###########################################################
+
+func (dict *dictImplementacion[K, V]) MarshalBinary() ([]byte, error) {
+	return dict.codecs.serializar(dict.elementos, dict.Iterar)
+}
+
+// UnmarshalBinary reemplaza el contenido del diccionario por el de los datos. La tabla se crea desde el principio
+// con lugar para todos los elementos, así la carga no redimensiona
+func (dict *dictImplementacion[K, V]) UnmarshalBinary(datos []byte) error {
+	pares, err := dict.codecs.deserializar(datos)
+	if err != nil {
+		return err
+	}
+	dict.reservar(len(pares))
+	for _, par := range pares {
+		dict.Guardar(par.clave, par.dato)
+	}
+	return nil
+}
+
+// reservar vacía el diccionario y le crea una tabla con lugar para la cantidad de elementos indicada
+func (dict *dictImplementacion[K, V]) reservar(cantidad int) {
+	dict.primo = 0
+	for dict.primo+1 < len(primos) && float32(cantidad) >= dict.maxFC*float32(primos[dict.primo]*dict.celdas) {
+		dict.primo++
+	}
+	dict.tabla = crearTabla[K, V](primos[dict.primo] * dict.celdas)
+	dict.stash = nil
+	dict.elementos = 0
+	dict.semillas = nuevasSemillas()
+	dict.modificaciones++
+}
+
+// ######################################## SWISS ###########################################################
+
+func (dict *dictSwiss[K, V]) MarshalBinary() ([]byte, error) {
+	return dict.codecs.serializar(dict.elementos, dict.Iterar)
+}
+
+// UnmarshalBinary reemplaza el contenido del diccionario por el de los datos, creando desde el principio una tabla
+// con lugar para todos los elementos
+func (dict *dictSwiss[K, V]) UnmarshalBinary(datos []byte) error {
+	pares, err := dict.codecs.deserializar(datos)
+	if err != nil {
+		return err
+	}
+	dict.reservar(len(pares))
+	for _, par := range pares {
+		dict.Guardar(par.clave, par.dato)
+	}
+	return nil
+}
+
+// reservar vacía el diccionario y le crea una tabla con lugar para la cantidad de elementos indicada
+func (dict *dictSwiss[K, V]) reservar(cantidad int) {
+	grupos := GRUPOS_INICIALES
+	for float32(cantidad) > MAX_FC_SWISS*float32(grupos*CELDAS_POR_GRUPO) {
+		grupos *= FACTOR_REDIMENSION
+	}
+	dict.grupos = crearGrupos[K, V](grupos)
+	dict.elementos, dict.borrados = 0, 0
+	dict.modificaciones++
+}
+
+// ###################################### CONCURRENTE #######################################################
+
+// MarshalBinary copia cada fragmento con su candado tomado, como Iterar. Si otras goroutines modifican el
+// diccionario mientras tanto, los datos pueden tener algunas de esas modificaciones y no otras
+func (dict *dictConcurrente[K, V]) MarshalBinary() ([]byte, error) {
+	var pares []parClaveValor[K, V]
+	for i := range dict.fragmentos {
+		pares = append(pares, dict.fragmentos[i].copiar()...)
+	}
+	return dict.codecs.serializar(len(pares), func(visitar func(K, V) bool) {
+		for _, par := range pares {
+			if !visitar(par.clave, par.dato) {
+				return
+			}
+		}
+	})
+}
+
+// UnmarshalBinary reemplaza el contenido del diccionario por el de los datos. Cada fragmento se carga con su
+// candado tomado y con una tabla creada con lugar para sus elementos, pero la carga no es atómica: otras goroutines
+// pueden ver algunos fragmentos ya cargados y otros no
+func (dict *dictConcurrente[K, V]) UnmarshalBinary(datos []byte) error {
+	pares, err := dict.codecs.deserializar(datos)
+	if err != nil {
+		return err
+	}
+	porFragmento := make([][]parClaveValor[K, V], len(dict.fragmentos))
+	for _, par := range pares {
+		i := dict.posicionFragmento(par.clave)
+		porFragmento[i] = append(porFragmento[i], par)
+	}
+	for i := range dict.fragmentos {
+		dict.fragmentos[i].cargar(porFragmento[i])
+	}
+	return nil
+}
+
+// cargar reemplaza el contenido del fragmento por los pares
+func (fragmento *fragmentoConcurrente[K, V]) cargar(pares []parClaveValor[K, V]) {
+	fragmento.Lock()
+	defer fragmento.Unlock()
+	fragmento.diccionario.(reservable).reservar(len(pares))
+	for _, par := range pares {
+		fragmento.diccionario.Guardar(par.clave, par.dato)
+	}
+}
+
+// ##################################### POR INSERCIÓN ######################################################
+
+// MarshalBinary guarda los pares en el orden del diccionario
+func (dict *dictPorInsercion[K, V]) MarshalBinary() ([]byte, error) {
+	return dict.codecs.serializar(dict.Cantidad(), dict.Iterar)
+}
+
+// UnmarshalBinary reemplaza el contenido del diccionario por el de los datos, en el orden en que aparecen. El
+// índice se crea desde el principio con lugar para todos los elementos
+func (dict *dictPorInsercion[K, V]) UnmarshalBinary(datos []byte) error {
+	pares, err := dict.codecs.deserializar(datos)
+	if err != nil {
+		return err
+	}
+	dict.indice.(reservable).reservar(len(pares))
+	dict.primero, dict.ultimo = nil, nil
+	dict.modificaciones++
+	for _, par := range pares {
+		dict.Guardar(par.clave, par.dato)
+	}
+	return nil
+}
+
+// ######################################### ABB ############################################################
+
+// MarshalBinary guarda los pares en el orden del árbol, con los codecs de CodecPorDefecto
+func (arbol *abb[K, V]) MarshalBinary() ([]byte, error) {
+	return SerializarBinario[K, V](arbol, nil, nil)
+}
+
+// UnmarshalBinary vacía el árbol antes de guardar los pares, en lugar de borrarlos de a uno
+func (arbol *abb[K, V]) UnmarshalBinary(datos []byte) error {
+	pares, err := crearCodecs(Opciones[K, V]{}).deserializar(datos)
+	if err != nil {
+		return err
+	}
+	arbol.raiz, arbol.cantidad = nil, 0
+	arbol.modificaciones++
+	for _, par := range pares {
+		arbol.Guardar(par.clave, par.dato)
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package diccionario_test
+
+import (
+	"cmp"
+	TDADiccionario "diccionario"
+	"diccionario/abierto"
+	"diccionario/cerrado"
+	"encoding"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var VARIANTES_BINARIO = map[string]TDADiccionario.Variante{
+	"Cuckoo":          TDADiccionario.CUCKOO,
+	"CuckooConBaldes": TDADiccionario.CUCKOO_CON_BALDES,
+	"Swiss":           TDADiccionario.SWISS_TABLE,
+}
+
+type punto struct {
+	X, Y     int
+	Etiqueta string
+}
+
+func serializar[K comparable, V any](t *testing.T, dic TDADiccionario.Diccionario[K, V]) []byte {
+	datos, err := dic.(encoding.BinaryMarshaler).MarshalBinary()
+	require.NoError(t, err)
+	return datos
+}
+
+func TestBinarioIdaYVuelta(t *testing.T) {
+	t.Log("Un diccionario cargado con UnmarshalBinary tiene los mismos pares que el que generó los datos, con " +
+		"los codecs por defecto para strings, enteros y gob")
+	for nombre, variante := range VARIANTES_BINARIO {
+		t.Run(nombre, func(t *testing.T) {
+			palabras := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[string, int]{Variante: variante})
+			puntos := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int64, punto]{Variante: variante})
+			for i := 0; i < 1000; i++ {
+				palabras.Guardar(fmt.Sprintf("palabra %d", i), -i)
+				puntos.Guardar(int64(i)<<40, punto{i, 2 * i, fmt.Sprint(i)})
+			}
+			palabras.Guardar("", 7)
+
+			copiaPalabras, err := TDADiccionario.CrearHashDesdeBinario(
+				TDADiccionario.Opciones[string, int]{Variante: variante}, serializar(t, palabras))
+			require.NoError(t, err)
+			copiaPuntos := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int64, punto]{Variante: variante})
+			copiaPuntos.Guardar(-1, punto{})
+			require.NoError(t, copiaPuntos.(encoding.BinaryUnmarshaler).UnmarshalBinary(serializar(t, puntos)))
+
+			require.EqualValues(t, palabras.Cantidad(), copiaPalabras.Cantidad())
+			for clave, dato := range palabras.All() {
+				require.EqualValues(t, dato, copiaPalabras.Obtener(clave))
+			}
+			require.EqualValues(t, puntos.Cantidad(), copiaPuntos.Cantidad())
+			require.False(t, copiaPuntos.Pertenece(-1))
+			for clave, dato := range puntos.All() {
+				require.EqualValues(t, dato, copiaPuntos.Obtener(clave))
+			}
+		})
+	}
+}
+
+func TestBinarioOtrosDiccionarios(t *testing.T) {
+	t.Log("Los diccionarios concurrente, ordenado, AVL, abierto y cerrados también implementan MarshalBinary y " +
+		"UnmarshalBinary, con el mismo formato que el Diccionario de hash")
+	constructores := map[string]func() TDADiccionario.Diccionario[string, int]{
+		"Concurrente": func() TDADiccionario.Diccionario[string, int] {
+			return TDADiccionario.CrearHashConcurrente[string, int]()
+		},
+		"PorInsercion": func() TDADiccionario.Diccionario[string, int] {
+			return TDADiccionario.CrearHashPorInsercion[string, int]()
+		},
+		"ABB": func() TDADiccionario.Diccionario[string, int] {
+			return TDADiccionario.CrearABB[string, int](cmp.Compare[string])
+		},
+		"Abierto":   abierto.CrearHashAbierto[string, int],
+		"Lineal":    cerrado.CrearHashLineal[string, int],
+		"RobinHood": cerrado.CrearHashRobinHood[string, int],
+	}
+	for nombre, crear := range constructores {
+		t.Run(nombre, func(t *testing.T) {
+			dic := crear()
+			for i := 0; i < 500; i++ {
+				dic.Guardar(fmt.Sprintf("clave %d", i), i)
+			}
+			datos := serializar(t, dic)
+
+			copia := crear()
+			copia.Guardar("vieja", -1)
+			require.NoError(t, copia.(encoding.BinaryUnmarshaler).UnmarshalBinary(datos))
+			require.False(t, copia.Pertenece("vieja"))
+			require.EqualValues(t, 500, copia.Cantidad())
+			for clave, dato := range dic.All() {
+				require.EqualValues(t, dato, copia.Obtener(clave))
+			}
+
+			hash, err := TDADiccionario.CrearHashDesdeBinario(TDADiccionario.Opciones[string, int]{}, datos)
+			require.NoError(t, err)
+			require.EqualValues(t, 500, hash.Cantidad())
+			require.NoError(t, copia.(encoding.BinaryUnmarshaler).UnmarshalBinary(serializar(t, hash)))
+			require.EqualValues(t, 500, copia.Cantidad())
+
+			require.ErrorIs(t, copia.(encoding.BinaryUnmarshaler).UnmarshalBinary(datos[:len(datos)-1]),
+				TDADiccionario.ErrDatosCorruptos)
+			require.EqualValues(t, 500, copia.Cantidad())
+		})
+	}
+}
+
+func TestBinarioPorInsercionConservaElOrden(t *testing.T) {
+	t.Log("El diccionario por inserción serializa los pares en su orden y los carga en el mismo orden")
+	dic := TDADiccionario.CrearHashPorInsercion[int, int]()
+	for i := 100; i > 0; i-- {
+		dic.Guardar(i, -i)
+	}
+	copia := TDADiccionario.CrearHashPorInsercion[int, int]()
+	require.NoError(t, copia.(encoding.BinaryUnmarshaler).UnmarshalBinary(serializar[int, int](t, dic)))
+	esperado := 100
+	for clave, dato := range copia.All() {
+		require.EqualValues(t, esperado, clave)
+		require.EqualValues(t, -esperado, dato)
+		esperado--
+	}
+	require.EqualValues(t, 0, esperado)
+}
+
+func TestBinarioNoSoportado(t *testing.T) {
+	t.Log("Los diccionarios con estado que el formato no guarda no implementan MarshalBinary, pero sus pares se " +
+		"pueden serializar con SerializarBinario y cargar con DeserializarBinario")
+	noSoportados := map[string]TDADiccionario.Diccionario[string, int]{
+		"CacheLRU":      TDADiccionario.CrearCacheLRU[string, int](10),
+		"ConExpiracion": TDADiccionario.CrearHashConExpiracion[string, int](),
+		"Bidireccional": TDADiccionario.CrearHashBidireccional[string, int](),
+	}
+	for nombre, dic := range noSoportados {
+		t.Run(nombre, func(t *testing.T) {
+			_, marshaler := dic.(encoding.BinaryMarshaler)
+			_, unmarshaler := dic.(encoding.BinaryUnmarshaler)
+			require.False(t, marshaler)
+			require.False(t, unmarshaler)
+
+			dic.Guardar("a", 1)
+			dic.Guardar("b", 2)
+			datos, err := TDADiccionario.SerializarBinario(dic, nil, nil)
+			require.NoError(t, err)
+			copia := TDADiccionario.CrearHash[string, int]()
+			require.NoError(t, TDADiccionario.DeserializarBinario(datos, copia, nil, nil))
+			require.EqualValues(t, 2, copia.Cantidad())
+			require.EqualValues(t, 2, copia.Obtener("b"))
+
+			copia.Guardar("c", 3)
+			datos, err = TDADiccionario.SerializarBinario(copia, TDADiccionario.CodecString[string]{}, nil)
+			require.NoError(t, err)
+			require.NoError(t, TDADiccionario.DeserializarBinario(datos, dic, nil, nil))
+			require.EqualValues(t, 3, dic.Cantidad())
+			require.EqualValues(t, 3, dic.Obtener("c"))
+		})
+	}
+}
+
+func TestBinarioVacio(t *testing.T) {
+	t.Log("Un diccionario vacío se serializa y se carga sin problemas")
+	dic := TDADiccionario.CrearHash[string, string]()
+	copia, err := TDADiccionario.CrearHashDesdeBinario(TDADiccionario.Opciones[string, string]{}, serializar(t, dic))
+	require.NoError(t, err)
+	require.EqualValues(t, 0, copia.Cantidad())
+}
+
+// codecConPrefijo guarda los strings sin cambios, pero al decodificarlos les agrega un prefijo, para poder ver que
+// el diccionario usó los codecs de las opciones
+type codecConPrefijo struct{}
+
+func (codecConPrefijo) Codificar(destino []byte, dato string) ([]byte, error) {
+	return append(destino, dato...), nil
+}
+
+func (codecConPrefijo) Decodificar(origen []byte) (string, error) {
+	return "cargado " + string(origen), nil
+}
+
+func TestBinarioCodecsPropios(t *testing.T) {
+	t.Log("El diccionario usa los codecs indicados en las opciones")
+	type id uint16
+	opciones := TDADiccionario.Opciones[id, string]{
+		CodecClaves: TDADiccionario.CodecEntero[id]{},
+		CodecDatos:  codecConPrefijo{},
+	}
+	dic := TDADiccionario.CrearHashCon(opciones)
+	dic.Guardar(65535, "a")
+	dic.Guardar(3, "b")
+	copia, err := TDADiccionario.CrearHashDesdeBinario(opciones, serializar(t, dic))
+	require.NoError(t, err)
+	require.EqualValues(t, "cargado a", copia.Obtener(65535))
+	require.EqualValues(t, "cargado b", copia.Obtener(3))
+}
+
+func TestBinarioErrores(t *testing.T) {
+	t.Log("UnmarshalBinary rechaza datos con otra firma, otra versión, un checksum que no coincide, o cortados, y " +
+		"en ese caso deja al diccionario como estaba")
+	for nombre, variante := range VARIANTES_BINARIO {
+		t.Run(nombre, func(t *testing.T) {
+			opciones := TDADiccionario.Opciones[string, int]{Variante: variante}
+			origen := TDADiccionario.CrearHashCon(opciones)
+			for i := 0; i < 100; i++ {
+				origen.Guardar(fmt.Sprint(i), i)
+			}
+			datos := serializar(t, origen)
+
+			dic := TDADiccionario.CrearHashCon(opciones)
+			dic.Guardar("A", 1)
+			dic.Guardar("B", 2)
+			cargar := func(datos []byte) error {
+				return dic.(encoding.BinaryUnmarshaler).UnmarshalBinary(datos)
+			}
+
+			otraFirma := append([]byte("XXXX"), datos[4:]...)
+			require.ErrorIs(t, cargar(otraFirma), TDADiccionario.ErrFormatoInvalido)
+
+			otraVersion := append([]byte{}, datos...)
+			otraVersion[len(TDADiccionario.FIRMA_BINARIO)] = TDADiccionario.VERSION_BINARIO + 1
+			require.ErrorIs(t, cargar(otraVersion), TDADiccionario.ErrVersionNoSoportada)
+
+			corruptos := append([]byte{}, datos...)
+			corruptos[len(corruptos)/2] ^= 0xFF
+			require.ErrorIs(t, cargar(corruptos), TDADiccionario.ErrDatosCorruptos)
+
+			require.Error(t, cargar(datos[:len(datos)-1]))
+			require.Error(t, cargar(datos[:3]))
+			require.Error(t, cargar(nil))
+
+			require.EqualValues(t, 2, dic.Cantidad())
+			require.EqualValues(t, 1, dic.Obtener("A"))
+			require.EqualValues(t, 2, dic.Obtener("B"))
+		})
+	}
+}
+
+func TestBinarioEnteroDeLargoInvalido(t *testing.T) {
+	t.Log("CodecEntero rechaza datos que no ocupan 8 bytes")
+	_, err := TDADiccionario.CodecEntero[int]{}.Decodificar([]byte{1, 2, 3})
+	require.ErrorIs(t, err, TDADiccionario.ErrFormatoInvalido)
+}
+
+func TestBinarioCargaSinRedimensionar(t *testing.T) {
+	t.Log("Cargar un diccionario con UnmarshalBinary hashea cada clave menos veces que guardarlas de a una, ya que " +
+		"la tabla se crea con el tamaño necesario y no se redimensiona")
+	const cantidad = 10000
+	for nombre, variante := range VARIANTES_BINARIO {
+		t.Run(nombre, func(t *testing.T) {
+			llamadosGuardando := 0
+			opciones := TDADiccionario.Opciones[int, int]{
+				Variante: variante,
+				Hasher:   hasherContador{&llamadosGuardando},
+			}
+			origen := TDADiccionario.CrearHashCon(opciones)
+			for i := 0; i < cantidad; i++ {
+				origen.Guardar(i, i)
+			}
+			datos := serializar(t, origen)
+
+			llamadosCargando := 0
+			opciones.Hasher = hasherContador{&llamadosCargando}
+			copia, err := TDADiccionario.CrearHashDesdeBinario(opciones, datos)
+			require.NoError(t, err)
+			require.EqualValues(t, cantidad, copia.Cantidad())
+			require.Less(t, llamadosCargando, llamadosGuardando)
+			if variante == TDADiccionario.SWISS_TABLE {
+				// Una búsqueda para ver que la clave no esté, y otra para ubicarla
+				require.EqualValues(t, 2*cantidad, llamadosCargando)
+			}
+		})
+	}
+}
+
+func TestBinarioOtrosDiccionariosCarganSinRedimensionar(t *testing.T) {
+	t.Log("Los diccionarios concurrente, abierto y cerrados reemplazan su contenido con UnmarshalBinary creando la " +
+		"tabla con el tamaño necesario, sin achicarla al descartar las claves viejas ni agrandarla al cargar las nuevas")
+	const cantidad = 5000
+	constructores := map[string]func() TDADiccionario.Diccionario[int, int]{
+		"Concurrente": func() TDADiccionario.Diccionario[int, int] {
+			return TDADiccionario.CrearHashConcurrente[int, int]()
+		},
+		"Abierto":   abierto.CrearHashAbierto[int, int],
+		"Lineal":    cerrado.CrearHashLineal[int, int],
+		"RobinHood": cerrado.CrearHashRobinHood[int, int],
+	}
+	for nombre, crear := range constructores {
+		t.Run(nombre, func(t *testing.T) {
+			origen := crear()
+			for i := 0; i < cantidad; i++ {
+				origen.Guardar(i, i)
+			}
+			require.Positive(t, estadisticasDe(origen).Agrandamientos)
+
+			copia := crear()
+			for i := 0; i < 2*cantidad; i++ {
+				copia.Guardar(-i, i)
+			}
+			copia.(TDADiccionario.DiccionarioConEstadisticas[int, int]).ReiniciarEstadisticas()
+			require.NoError(t, copia.(encoding.BinaryUnmarshaler).UnmarshalBinary(serializar(t, origen)))
+			estadisticas := estadisticasDe(copia)
+			require.EqualValues(t, 0, estadisticas.Agrandamientos)
+			require.EqualValues(t, 0, estadisticas.Achicamientos)
+			require.EqualValues(t, cantidad, estadisticas.Elementos)
+			require.False(t, copia.Pertenece(-1))
+			require.EqualValues(t, cantidad-1, copia.Obtener(cantidad-1))
+		})
+	}
+}
+
+func TestBinarioPorInsercionCargaSinRedimensionar(t *testing.T) {
+	t.Log("El diccionario por inserción crea su índice con el tamaño necesario antes de cargar los pares, así " +
+		"AlRedimensionar no se llama durante UnmarshalBinary")
+	const cantidad = 5000
+	origen := TDADiccionario.CrearHashPorInsercion[int, int]()
+	for i := 0; i < cantidad; i++ {
+		origen.Guardar(i, i)
+	}
+	redimensiones := 0
+	copia := TDADiccionario.CrearHashPorInsercionCon(TDADiccionario.ORDEN_INSERCION, TDADiccionario.Opciones[int, int]{
+		AlRedimensionar: func(int, int) { redimensiones++ },
+	})
+	for i := 0; i < 2*cantidad; i++ {
+		copia.Guardar(-i, i)
+	}
+	require.Positive(t, redimensiones)
+	redimensiones = 0
+	require.NoError(t, copia.(encoding.BinaryUnmarshaler).UnmarshalBinary(serializar[int, int](t, origen)))
+	require.EqualValues(t, 0, redimensiones)
+	require.EqualValues(t, cantidad, copia.Cantidad())
+	primero, _ := copia.Primero()
+	ultimo, _ := copia.Ultimo()
+	require.EqualValues(t, 0, primero)
+	require.EqualValues(t, cantidad-1, ultimo)
+}
@@ -0,0 +1,77 @@
+package diccionario
+
+// EstadisticasCache cuenta los aciertos, fallos y desalojos de una CacheLRU
+type EstadisticasCache struct {
+	Aciertos  int
+	Fallos    int
+	Desalojos int
+}
+
+/* La cache es un DiccionarioPorInsercion con ORDEN_ACCESO: el primer elemento es siempre el usado hace más
+tiempo, y es el que se desaloja. Las primitivas que no cuentan como uso, y los recorridos, son las del diccionario
+por inserción sin cambios.
+*/
+
+type cacheLRU[K comparable, V any] struct {
+	DiccionarioPorInsercion[K, V]
+	capacidad    int
+	alDesalojar  func(K, V)
+	estadisticas EstadisticasCache
+}
+
+// CrearCacheLRU crea una CacheLRU con la capacidad indicada. Si la capacidad es menor a uno, entra en pánico con un
+// mensaje 'La capacidad debe ser mayor a cero'
+func CrearCacheLRU[K comparable, V any](capacidad int) CacheLRU[K, V] {
+	return CrearCacheLRUCon[K, V](capacidad, nil)
+}
+
+// CrearCacheLRUCon es como CrearCacheLRU, pero llama a alDesalojar con la clave y el dato de cada elemento que la
+// cache desaloja por falta de lugar (no con los que se borran con Borrar). alDesalojar puede ser nil
+func CrearCacheLRUCon[K comparable, V any](capacidad int, alDesalojar func(clave K, dato V)) CacheLRU[K, V] {
+	if capacidad < 1 {
+		panic(ErrCapacidadInvalida.Error())
+	}
+	return &cacheLRU[K, V]{
+		DiccionarioPorInsercion: CrearHashPorInsercionCon(ORDEN_ACCESO, Opciones[K, V]{}),
+		capacidad:               capacidad,
+		alDesalojar:             alDesalojar,
+	}
+}
+
+func (cache *cacheLRU[K, V]) Guardar(clave K, dato V) {
+	if cache.Cantidad() == cache.capacidad && !cache.Pertenece(clave) {
+		claveVieja, datoViejo := cache.Primero()
+		cache.Borrar(claveVieja)
+		cache.estadisticas.Desalojos++
+		if cache.alDesalojar != nil {
+			cache.alDesalojar(claveVieja, datoViejo)
+		}
+	}
+	cache.DiccionarioPorInsercion.Guardar(clave, dato)
+}
+
+func (cache *cacheLRU[K, V]) Obtener(clave K) V {
+	dato, ok := cache.ObtenerOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (cache *cacheLRU[K, V]) ObtenerOk(clave K) (V, bool) {
+	dato, ok := cache.DiccionarioPorInsercion.ObtenerOk(clave)
+	if ok {
+		cache.estadisticas.Aciertos++
+	} else {
+		cache.estadisticas.Fallos++
+	}
+	return dato, ok
+}
+
+func (cache *cacheLRU[K, V]) Capacidad() int {
+	return cache.capacidad
+}
+
+func (cache *cacheLRU[K, V]) Stats() EstadisticasCache {
+	return cache.estadisticas
+}
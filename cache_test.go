@@ -0,0 +1,71 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"testing"
+)
+
+func TestCacheLRUDesalojaLaMenosUsada(t *testing.T) {
+	t.Log("Al guardar una clave nueva con la cache llena se desaloja la usada hace más tiempo, contando como uso " +
+		"Guardar y Obtener, pero no Pertenece")
+	cache := TDADiccionario.CrearCacheLRU[string, int](3)
+	require.EqualValues(t, 3, cache.Capacidad())
+	cache.Guardar("A", 1)
+	cache.Guardar("B", 2)
+	cache.Guardar("C", 3)
+	cache.Obtener("A")
+	require.True(t, cache.Pertenece("B"))
+	cache.Guardar("D", 4)
+	require.False(t, cache.Pertenece("B"))
+	require.Equal(t, []string{"C", "A", "D"}, slices.Collect(cache.Claves()))
+
+	cache.Guardar("C", 30)
+	cache.Guardar("E", 5)
+	require.False(t, cache.Pertenece("A"))
+	require.Equal(t, []string{"D", "C", "E"}, slices.Collect(cache.Claves()))
+	require.EqualValues(t, 3, cache.Cantidad())
+	require.EqualValues(t, 30, cache.Obtener("C"))
+}
+
+func TestCacheLRUAlDesalojar(t *testing.T) {
+	t.Log("La función de desalojo recibe cada clave desalojada con su dato, y no se llama al reemplazar datos ni " +
+		"al borrar claves")
+	var desalojadas []string
+	var datos []int
+	cache := TDADiccionario.CrearCacheLRUCon(2, func(clave string, dato int) {
+		desalojadas = append(desalojadas, clave)
+		datos = append(datos, dato)
+	})
+	cache.Guardar("A", 1)
+	cache.Guardar("B", 2)
+	cache.Guardar("A", 10)
+	cache.Borrar("B")
+	cache.Guardar("C", 3)
+	require.Empty(t, desalojadas)
+	cache.Guardar("D", 4)
+	cache.Guardar("E", 5)
+	require.Equal(t, []string{"A", "C"}, desalojadas)
+	require.Equal(t, []int{10, 3}, datos)
+}
+
+func TestCacheLRUStats(t *testing.T) {
+	t.Log("Stats cuenta aciertos y fallos de Obtener y ObtenerOk, y los desalojos")
+	cache := TDADiccionario.CrearCacheLRU[int, int](10)
+	for i := 0; i < 15; i++ {
+		cache.Guardar(i, i)
+	}
+	for i := 0; i < 15; i++ {
+		cache.ObtenerOk(i)
+	}
+	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { cache.Obtener(0) })
+	cache.Obtener(14)
+	cache.Pertenece(0)
+	require.Equal(t, TDADiccionario.EstadisticasCache{Aciertos: 11, Fallos: 6, Desalojos: 5}, cache.Stats())
+}
+
+func TestCacheLRUCapacidadInvalida(t *testing.T) {
+	t.Log("No se puede crear una cache sin capacidad")
+	require.PanicsWithValue(t, "La capacidad debe ser mayor a cero", func() { TDADiccionario.CrearCacheLRU[int, int](0) })
+}
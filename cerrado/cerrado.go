@@ -0,0 +1,167 @@
+package cerrado
+
+import (
+	TDADiccionario "diccionario"
+	"hash/maphash"
+	"iter"
+)
+
+const (
+	CAPACIDAD_INICIAL  = 128
+	MAX_FC             = 0.7
+	MIN_FC             = 0.1
+	FACTOR_REDIMENSION = 2
+)
+
+type estadoCelda int
+
+const (
+	VACIO estadoCelda = iota
+	OCUPADO
+	BORRADO
+)
+
+type celda[K comparable, V any] struct {
+	clave  K
+	dato   V
+	estado estadoCelda
+
+	// distancia es cuántas posiciones está la celda corrida respecto de la que le asigna la función de hash. Solo la
+	// usa Robin Hood
+	distancia int
+}
+
+// hashCerrado tiene lo común a las implementaciones de direccionamiento abierto: todos los elementos se guardan en
+// la propia tabla, y las colisiones se resuelven probando las posiciones siguientes. La capacidad es siempre una
+// potencia de 2
+type hashCerrado[K comparable, V any] struct {
+	tabla    []celda[K, V]
+	cantidad int
+	hasher   TDADiccionario.Hasher[K]
+	semilla  maphash.Seed
+
+	// agrandamientos y achicamientos cuentan las redimensiones, para Estadisticas
+	agrandamientos int
+	achicamientos  int
+}
+
+type iteradorCerrado[K comparable, V any] struct {
+	hash     *hashCerrado[K, V]
+	posicion int
+}
+
+func crearHashCerrado[K comparable, V any]() hashCerrado[K, V] {
+	return hashCerrado[K, V]{
+		tabla:   make([]celda[K, V], CAPACIDAD_INICIAL),
+		hasher:  TDADiccionario.HasherPorDefecto[K](),
+		semilla: maphash.MakeSeed(),
+	}
+}
+
+func (hash *hashCerrado[K, V]) posicionInicial(clave K) int {
+	return int(hash.hasher.Hashear(clave, TDADiccionario.PRIMER_HASH, hash.semilla) & uint64(len(hash.tabla)-1))
+}
+
+func (hash *hashCerrado[K, V]) siguiente(posicion int) int {
+	return (posicion + 1) & (len(hash.tabla) - 1)
+}
+
+// capacidadPara devuelve la menor capacidad, desde CAPACIDAD_INICIAL, con lugar para la cantidad de elementos
+// indicada
+func capacidadPara(cantidad int) int {
+	capacidad := CAPACIDAD_INICIAL
+	for float32(cantidad)/float32(capacidad) > MAX_FC {
+		capacidad *= FACTOR_REDIMENSION
+	}
+	return capacidad
+}
+
+// redimensionado actualiza los contadores de Estadisticas antes de reemplazar la tabla por una de la capacidad
+// indicada
+func (hash *hashCerrado[K, V]) redimensionado(nuevaCapacidad int) {
+	if nuevaCapacidad > len(hash.tabla) {
+		hash.agrandamientos++
+	} else if nuevaCapacidad < len(hash.tabla) {
+		hash.achicamientos++
+	}
+}
+
+// pocaCarga indica si conviene achicar la tabla luego de un borrado
+func (hash *hashCerrado[K, V]) pocaCarga() bool {
+	return float32(hash.cantidad)/float32(len(hash.tabla)) < MIN_FC && len(hash.tabla) > CAPACIDAD_INICIAL
+}
+
+// Estadisticas informa la capacidad, la carga y las redimensiones de la tabla. Los demás contadores son de los
+// diccionarios de TDADiccionario.CrearHashCon y quedan en cero
+func (hash *hashCerrado[K, V]) Estadisticas() TDADiccionario.EstadisticasHash {
+	return TDADiccionario.EstadisticasHash{
+		Capacidad:      len(hash.tabla),
+		Elementos:      hash.cantidad,
+		FactorDeCarga:  float64(hash.cantidad) / float64(len(hash.tabla)),
+		Agrandamientos: hash.agrandamientos,
+		Achicamientos:  hash.achicamientos,
+	}
+}
+
+func (hash *hashCerrado[K, V]) ReiniciarEstadisticas() {
+	hash.agrandamientos, hash.achicamientos = 0, 0
+}
+
+func (hash *hashCerrado[K, V]) Cantidad() int {
+	return hash.cantidad
+}
+
+func (hash *hashCerrado[K, V]) Iterar(visitar func(K, V) bool) {
+	for i := range hash.tabla {
+		if hash.tabla[i].estado == OCUPADO && !visitar(hash.tabla[i].clave, hash.tabla[i].dato) {
+			return
+		}
+	}
+}
+
+func (hash *hashCerrado[K, V]) All() iter.Seq2[K, V] {
+	return hash.Iterar
+}
+
+func (hash *hashCerrado[K, V]) Claves() iter.Seq[K] {
+	return func(visitar func(K) bool) {
+		hash.Iterar(func(clave K, _ V) bool { return visitar(clave) })
+	}
+}
+
+func (hash *hashCerrado[K, V]) Valores() iter.Seq[V] {
+	return func(visitar func(V) bool) {
+		hash.Iterar(func(_ K, dato V) bool { return visitar(dato) })
+	}
+}
+
+func (hash *hashCerrado[K, V]) Iterador() TDADiccionario.IterDiccionario[K, V] {
+	return &iteradorCerrado[K, V]{hash: hash, posicion: hash.siguienteOcupada(0)}
+}
+
+func (hash *hashCerrado[K, V]) siguienteOcupada(desde int) int {
+	for desde < len(hash.tabla) && hash.tabla[desde].estado != OCUPADO {
+		desde++
+	}
+	return desde
+}
+
+func (iter *iteradorCerrado[K, V]) HaySiguiente() bool {
+	return iter.posicion < len(iter.hash.tabla)
+}
+
+func (iter *iteradorCerrado[K, V]) VerActual() (K, V) {
+	if !iter.HaySiguiente() {
+		panic(TDADiccionario.ErrIteradorTerminado.Error())
+	}
+	return iter.hash.tabla[iter.posicion].clave, iter.hash.tabla[iter.posicion].dato
+}
+
+func (iter *iteradorCerrado[K, V]) Siguiente() K {
+	if !iter.HaySiguiente() {
+		panic(TDADiccionario.ErrIteradorTerminado.Error())
+	}
+	actual := iter.hash.tabla[iter.posicion].clave
+	iter.posicion = iter.hash.siguienteOcupada(iter.posicion + 1)
+	return actual
+}
@@ -0,0 +1,156 @@
+package cerrado
+
+import (
+	"bytes"
+	TDADiccionario "diccionario"
+)
+
+// hashLineal resuelve las colisiones con sondeo lineal. Los elementos borrados dejan su celda en estado BORRADO,
+// para no cortar la secuencia de sondeo de las claves que estén más adelante; esas celdas se reutilizan al guardar
+// y se descartan al redimensionar
+type hashLineal[K comparable, V any] struct {
+	hashCerrado[K, V]
+	borrados int
+}
+
+// CrearHashLineal crea un Diccionario de direccionamiento abierto con sondeo lineal y borrado por marcas. Conviene
+// para cargas con muchas más búsquedas que borrados
+func CrearHashLineal[K comparable, V any]() TDADiccionario.Diccionario[K, V] {
+	return &hashLineal[K, V]{hashCerrado: crearHashCerrado[K, V]()}
+}
+
+// buscar devuelve la posición de la clave y true si pertenece. Si no, devuelve la posición donde guardarla (la
+// primera celda borrada del recorrido, o la vacía en la que terminó) y false
+func (hash *hashLineal[K, V]) buscar(clave K) (int, bool) {
+	primerBorrado := -1
+	posicion := hash.posicionInicial(clave)
+	for hash.tabla[posicion].estado != VACIO {
+		if hash.tabla[posicion].estado == OCUPADO && hash.tabla[posicion].clave == clave {
+			return posicion, true
+		}
+		if hash.tabla[posicion].estado == BORRADO && primerBorrado == -1 {
+			primerBorrado = posicion
+		}
+		posicion = hash.siguiente(posicion)
+	}
+	if primerBorrado != -1 {
+		return primerBorrado, false
+	}
+	return posicion, false
+}
+
+func (hash *hashLineal[K, V]) redimensionar(nuevaCapacidad int) {
+	hash.redimensionado(nuevaCapacidad)
+	anterior := hash.tabla
+	hash.tabla = make([]celda[K, V], nuevaCapacidad)
+	hash.borrados = 0
+	for i := range anterior {
+		if anterior[i].estado == OCUPADO {
+			posicion, _ := hash.buscar(anterior[i].clave)
+			hash.tabla[posicion] = celda[K, V]{clave: anterior[i].clave, dato: anterior[i].dato, estado: OCUPADO}
+		}
+	}
+}
+
+func (hash *hashLineal[K, V]) Guardar(clave K, dato V) {
+	posicion, pertenece := hash.buscar(clave)
+	if pertenece {
+		hash.tabla[posicion].dato = dato
+		return
+	}
+
+	// Las celdas borradas también alargan los sondeos, así que cuentan para la carga
+	if float32(hash.cantidad+hash.borrados+1)/float32(len(hash.tabla)) > MAX_FC {
+		capacidad := len(hash.tabla)
+		if float32(hash.cantidad+1)/float32(len(hash.tabla)) > MAX_FC/FACTOR_REDIMENSION {
+			capacidad *= FACTOR_REDIMENSION
+		}
+		hash.redimensionar(capacidad)
+		posicion, _ = hash.buscar(clave)
+	}
+	if hash.tabla[posicion].estado == BORRADO {
+		hash.borrados--
+	}
+	hash.tabla[posicion] = celda[K, V]{clave: clave, dato: dato, estado: OCUPADO}
+	hash.cantidad++
+}
+
+func (hash *hashLineal[K, V]) Pertenece(clave K) bool {
+	_, pertenece := hash.buscar(clave)
+	return pertenece
+}
+
+func (hash *hashLineal[K, V]) Obtener(clave K) V {
+	dato, ok := hash.ObtenerOk(clave)
+	if !ok {
+		panic(TDADiccionario.ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (hash *hashLineal[K, V]) ObtenerOk(clave K) (V, bool) {
+	posicion, pertenece := hash.buscar(clave)
+	if !pertenece {
+		var cero V
+		return cero, false
+	}
+	return hash.tabla[posicion].dato, true
+}
+
+func (hash *hashLineal[K, V]) Borrar(clave K) V {
+	dato, ok := hash.BorrarOk(clave)
+	if !ok {
+		panic(TDADiccionario.ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (hash *hashLineal[K, V]) BorrarOk(clave K) (V, bool) {
+	posicion, pertenece := hash.buscar(clave)
+	if !pertenece {
+		var cero V
+		return cero, false
+	}
+	dato := hash.tabla[posicion].dato
+	hash.tabla[posicion] = celda[K, V]{estado: BORRADO}
+	hash.cantidad--
+	hash.borrados++
+
+	if hash.pocaCarga() {
+		hash.redimensionar(len(hash.tabla) / FACTOR_REDIMENSION)
+	}
+	return dato, true
+}
+
+// MarshalBinary genera los mismos datos que el Diccionario de TDADiccionario.CrearHash, con los codecs de
+// CodecPorDefecto
+func (hash *hashLineal[K, V]) MarshalBinary() ([]byte, error) {
+	return TDADiccionario.SerializarBinario[K, V](hash, nil, nil)
+}
+
+// UnmarshalBinary reemplaza el contenido del diccionario por el de los datos. La tabla se crea desde el principio
+// con lugar para todos los elementos, así la carga no redimensiona
+func (hash *hashLineal[K, V]) UnmarshalBinary(datos []byte) error {
+	pares, cantidad, err := TDADiccionario.DecodificarBinario[K, V](datos, nil, nil)
+	if err != nil {
+		return err
+	}
+	hash.tabla = make([]celda[K, V], capacidadPara(cantidad))
+	hash.cantidad = 0
+	hash.borrados = 0
+	for clave, dato := range pares {
+		hash.Guardar(clave, dato)
+	}
+	return nil
+}
+
+// MarshalJSON escribe los pares como un objeto JSON, como el Diccionario de TDADiccionario.CrearHash
+func (hash *hashLineal[K, V]) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	err := TDADiccionario.CodificarJSON[K, V](&buffer, hash, false)
+	return buffer.Bytes(), err
+}
+
+func (hash *hashLineal[K, V]) UnmarshalJSON(datos []byte) error {
+	return TDADiccionario.DecodificarJSON[K, V](bytes.NewReader(datos), hash)
+}
@@ -0,0 +1,159 @@
+package cerrado
+
+import (
+	"bytes"
+	TDADiccionario "diccionario"
+)
+
+// hashRobinHood resuelve las colisiones con sondeo lineal, pero al guardar le cede la celda al elemento que esté
+// más lejos de su posición inicial. Así las distancias quedan parejas y una búsqueda fallida puede cortar en cuanto
+// encuentra un elemento más cerca de su posición que la clave buscada. Al borrar, en lugar de dejar marcas, corre
+// hacia atrás a los elementos siguientes
+type hashRobinHood[K comparable, V any] struct {
+	hashCerrado[K, V]
+}
+
+// CrearHashRobinHood crea un Diccionario de direccionamiento abierto con hashing Robin Hood y borrado por
+// corrimiento hacia atrás. Conviene para cargas con muchos borrados, ya que no acumula celdas borradas
+func CrearHashRobinHood[K comparable, V any]() TDADiccionario.Diccionario[K, V] {
+	return &hashRobinHood[K, V]{hashCerrado: crearHashCerrado[K, V]()}
+}
+
+func (hash *hashRobinHood[K, V]) buscar(clave K) (int, bool) {
+	posicion := hash.posicionInicial(clave)
+	for distancia := 0; hash.tabla[posicion].estado == OCUPADO; distancia++ {
+		if hash.tabla[posicion].distancia < distancia {
+			return posicion, false
+		}
+		if hash.tabla[posicion].clave == clave {
+			return posicion, true
+		}
+		posicion = hash.siguiente(posicion)
+	}
+	return posicion, false
+}
+
+// ubicar guarda una clave que no pertenece a la tabla, desplazando a los elementos que estén más cerca de su
+// posición inicial que el que se está guardando
+func (hash *hashRobinHood[K, V]) ubicar(clave K, dato V) {
+	actual := celda[K, V]{clave: clave, dato: dato, estado: OCUPADO}
+	posicion := hash.posicionInicial(clave)
+	for hash.tabla[posicion].estado == OCUPADO {
+		if hash.tabla[posicion].distancia < actual.distancia {
+			actual, hash.tabla[posicion] = hash.tabla[posicion], actual
+		}
+		actual.distancia++
+		posicion = hash.siguiente(posicion)
+	}
+	hash.tabla[posicion] = actual
+}
+
+func (hash *hashRobinHood[K, V]) redimensionar(nuevaCapacidad int) {
+	hash.redimensionado(nuevaCapacidad)
+	anterior := hash.tabla
+	hash.tabla = make([]celda[K, V], nuevaCapacidad)
+	for i := range anterior {
+		if anterior[i].estado == OCUPADO {
+			hash.ubicar(anterior[i].clave, anterior[i].dato)
+		}
+	}
+}
+
+func (hash *hashRobinHood[K, V]) Guardar(clave K, dato V) {
+	if posicion, pertenece := hash.buscar(clave); pertenece {
+		hash.tabla[posicion].dato = dato
+		return
+	}
+
+	if float32(hash.cantidad+1)/float32(len(hash.tabla)) > MAX_FC {
+		hash.redimensionar(len(hash.tabla) * FACTOR_REDIMENSION)
+	}
+	hash.ubicar(clave, dato)
+	hash.cantidad++
+}
+
+func (hash *hashRobinHood[K, V]) Pertenece(clave K) bool {
+	_, pertenece := hash.buscar(clave)
+	return pertenece
+}
+
+func (hash *hashRobinHood[K, V]) Obtener(clave K) V {
+	dato, ok := hash.ObtenerOk(clave)
+	if !ok {
+		panic(TDADiccionario.ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (hash *hashRobinHood[K, V]) ObtenerOk(clave K) (V, bool) {
+	posicion, pertenece := hash.buscar(clave)
+	if !pertenece {
+		var cero V
+		return cero, false
+	}
+	return hash.tabla[posicion].dato, true
+}
+
+func (hash *hashRobinHood[K, V]) Borrar(clave K) V {
+	dato, ok := hash.BorrarOk(clave)
+	if !ok {
+		panic(TDADiccionario.ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (hash *hashRobinHood[K, V]) BorrarOk(clave K) (V, bool) {
+	posicion, pertenece := hash.buscar(clave)
+	if !pertenece {
+		var cero V
+		return cero, false
+	}
+	dato := hash.tabla[posicion].dato
+
+	// Corrimiento hacia atrás: cada elemento que no está en su posición inicial avanza una celda hacia ella
+	siguiente := hash.siguiente(posicion)
+	for hash.tabla[siguiente].estado == OCUPADO && hash.tabla[siguiente].distancia > 0 {
+		hash.tabla[posicion] = hash.tabla[siguiente]
+		hash.tabla[posicion].distancia--
+		posicion, siguiente = siguiente, hash.siguiente(siguiente)
+	}
+	hash.tabla[posicion] = celda[K, V]{}
+	hash.cantidad--
+
+	if hash.pocaCarga() {
+		hash.redimensionar(len(hash.tabla) / FACTOR_REDIMENSION)
+	}
+	return dato, true
+}
+
+// MarshalBinary genera los mismos datos que el Diccionario de TDADiccionario.CrearHash, con los codecs de
+// CodecPorDefecto
+func (hash *hashRobinHood[K, V]) MarshalBinary() ([]byte, error) {
+	return TDADiccionario.SerializarBinario[K, V](hash, nil, nil)
+}
+
+// UnmarshalBinary reemplaza el contenido del diccionario por el de los datos. La tabla se crea desde el principio
+// con lugar para todos los elementos, así la carga no redimensiona
+func (hash *hashRobinHood[K, V]) UnmarshalBinary(datos []byte) error {
+	pares, cantidad, err := TDADiccionario.DecodificarBinario[K, V](datos, nil, nil)
+	if err != nil {
+		return err
+	}
+	hash.tabla = make([]celda[K, V], capacidadPara(cantidad))
+	hash.cantidad = 0
+	for clave, dato := range pares {
+		hash.Guardar(clave, dato)
+	}
+	return nil
+}
+
+// MarshalJSON escribe los pares como un objeto JSON, como el Diccionario de TDADiccionario.CrearHash
+func (hash *hashRobinHood[K, V]) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	err := TDADiccionario.CodificarJSON[K, V](&buffer, hash, false)
+	return buffer.Bytes(), err
+}
+
+func (hash *hashRobinHood[K, V]) UnmarshalJSON(datos []byte) error {
+	return TDADiccionario.DecodificarJSON[K, V](bytes.NewReader(datos), hash)
+}
@@ -0,0 +1,246 @@
+package diccionario
+
+import (
+	"hash/maphash"
+	"iter"
+	"sync"
+)
+
+const FRAGMENTOS_POR_DEFECTO = 32
+
+/* El diccionario concurrente reparte las claves en fragmentos, cada uno con su propio diccionario y su propio
+candado, así las operaciones sobre claves de fragmentos distintos no se bloquean entre sí. El fragmento de una clave
+se elige con la ULTIMO_HASH del hasher y una semilla propia, distinta de las que usan los diccionarios de cada
+fragmento, para que las claves de un mismo fragmento no terminen todas en las mismas posiciones de su tabla.
+*/
+
+type dictConcurrente[K comparable, V any] struct {
+	fragmentos   []fragmentoConcurrente[K, V]
+	hasher       Hasher[K]
+	semilla      maphash.Seed
+	jsonOrdenado bool
+	codecs       codecsDiccionario[K, V]
+}
+
+type fragmentoConcurrente[K comparable, V any] struct {
+	sync.RWMutex
+	diccionario Diccionario[K, V]
+}
+
+type parClaveValor[K comparable, V any] struct {
+	clave K
+	dato  V
+}
+
+// iteradorConcurrente recorre una copia de un fragmento por vez. La copia de cada fragmento se toma recién cuando
+// el iterador llega a él, por lo que ve los cambios hechos a los fragmentos que todavía no recorrió
+type iteradorConcurrente[K comparable, V any] struct {
+	diccionario *dictConcurrente[K, V]
+	fragmento   int
+	pares       []parClaveValor[K, V]
+	posicion    int
+}
+
+func CrearHashConcurrente[K comparable, V any]() DiccionarioConcurrente[K, V] {
+	return CrearHashConcurrenteCon(FRAGMENTOS_POR_DEFECTO, Opciones[K, V]{})
+}
+
+// CrearHashConcurrenteCon crea un DiccionarioConcurrente con la cantidad de fragmentos indicada, cada uno creado
+// con CrearHashCon y las opciones recibidas
+func CrearHashConcurrenteCon[K comparable, V any](fragmentos int, opciones Opciones[K, V]) DiccionarioConcurrente[K, V] {
+	if fragmentos <= 0 {
+		fragmentos = FRAGMENTOS_POR_DEFECTO
+	}
+	dict := new(dictConcurrente[K, V])
+	dict.fragmentos = make([]fragmentoConcurrente[K, V], fragmentos)
+	for i := range dict.fragmentos {
+		dict.fragmentos[i].diccionario = CrearHashCon(opciones)
+	}
+	dict.hasher = opciones.Hasher
+	if dict.hasher == nil {
+		dict.hasher = HasherPorDefecto[K]()
+	}
+	dict.semilla = maphash.MakeSeed()
+	dict.jsonOrdenado = opciones.JSONOrdenado
+	dict.codecs = crearCodecs(opciones)
+	return dict
+}
+
+func (dict *dictConcurrente[K, V]) fragmento(clave K) *fragmentoConcurrente[K, V] {
+	return &dict.fragmentos[dict.posicionFragmento(clave)]
+}
+
+func (dict *dictConcurrente[K, V]) posicionFragmento(clave K) int {
+	hash := dict.hasher.Hashear(clave, ULTIMO_HASH, dict.semilla)
+	return int(hash % uint64(len(dict.fragmentos)))
+}
+
+// copiar devuelve los pares del fragmento, leídos mientras se tiene su candado
+func (fragmento *fragmentoConcurrente[K, V]) copiar() []parClaveValor[K, V] {
+	fragmento.RLock()
+	defer fragmento.RUnlock()
+	pares := make([]parClaveValor[K, V], 0, fragmento.diccionario.Cantidad())
+	fragmento.diccionario.Iterar(func(clave K, dato V) bool {
+		pares = append(pares, parClaveValor[K, V]{clave, dato})
+		return true
+	})
+	return pares
+}
+
+// ################################### PRIMITIVAS DICCIONARIO #################################################
+
+func (dict *dictConcurrente[K, V]) Guardar(clave K, dato V) {
+	fragmento := dict.fragmento(clave)
+	fragmento.Lock()
+	defer fragmento.Unlock()
+	fragmento.diccionario.Guardar(clave, dato)
+}
+
+func (dict *dictConcurrente[K, V]) Pertenece(clave K) bool {
+	_, ok := dict.ObtenerOk(clave)
+	return ok
+}
+
+func (dict *dictConcurrente[K, V]) Obtener(clave K) V {
+	dato, ok := dict.ObtenerOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (dict *dictConcurrente[K, V]) ObtenerOk(clave K) (V, bool) {
+	fragmento := dict.fragmento(clave)
+	fragmento.RLock()
+	defer fragmento.RUnlock()
+	return fragmento.diccionario.ObtenerOk(clave)
+}
+
+func (dict *dictConcurrente[K, V]) Borrar(clave K) V {
+	dato, ok := dict.BorrarOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (dict *dictConcurrente[K, V]) BorrarOk(clave K) (V, bool) {
+	fragmento := dict.fragmento(clave)
+	fragmento.Lock()
+	defer fragmento.Unlock()
+	return fragmento.diccionario.BorrarOk(clave)
+}
+
+// Cantidad suma las cantidades de los fragmentos de a uno. Si hay otras goroutines modificando el diccionario, el
+// resultado puede no coincidir con la cantidad en ningún momento en particular
+func (dict *dictConcurrente[K, V]) Cantidad() int {
+	cantidad := 0
+	for i := range dict.fragmentos {
+		fragmento := &dict.fragmentos[i]
+		fragmento.RLock()
+		cantidad += fragmento.diccionario.Cantidad()
+		fragmento.RUnlock()
+	}
+	return cantidad
+}
+
+// Iterar recorre una copia de cada fragmento, así la función puede usar el diccionario sin bloquearse
+func (dict *dictConcurrente[K, V]) Iterar(visitar func(K, V) bool) {
+	for i := range dict.fragmentos {
+		for _, par := range dict.fragmentos[i].copiar() {
+			if !visitar(par.clave, par.dato) {
+				return
+			}
+		}
+	}
+}
+
+func (dict *dictConcurrente[K, V]) All() iter.Seq2[K, V] {
+	return dict.Iterar
+}
+
+func (dict *dictConcurrente[K, V]) Claves() iter.Seq[K] {
+	return func(visitar func(K) bool) {
+		dict.Iterar(func(clave K, _ V) bool { return visitar(clave) })
+	}
+}
+
+func (dict *dictConcurrente[K, V]) Valores() iter.Seq[V] {
+	return func(visitar func(V) bool) {
+		dict.Iterar(func(_ K, dato V) bool { return visitar(dato) })
+	}
+}
+
+// ############################# OPERACIONES COMPUESTAS ###############################################
+
+func (dict *dictConcurrente[K, V]) GuardarSiNoExiste(clave K, dato V) bool {
+	fragmento := dict.fragmento(clave)
+	fragmento.Lock()
+	defer fragmento.Unlock()
+	if fragmento.diccionario.Pertenece(clave) {
+		return false
+	}
+	fragmento.diccionario.Guardar(clave, dato)
+	return true
+}
+
+func (dict *dictConcurrente[K, V]) ObtenerOGuardar(clave K, dato V) (V, bool) {
+	fragmento := dict.fragmento(clave)
+	fragmento.Lock()
+	defer fragmento.Unlock()
+	if actual, ok := fragmento.diccionario.ObtenerOk(clave); ok {
+		return actual, true
+	}
+	fragmento.diccionario.Guardar(clave, dato)
+	return dato, false
+}
+
+func (dict *dictConcurrente[K, V]) Actualizar(clave K, actualizar func(V, bool) V) V {
+	fragmento := dict.fragmento(clave)
+	fragmento.Lock()
+	defer fragmento.Unlock()
+	dato := actualizar(fragmento.diccionario.ObtenerOk(clave))
+	fragmento.diccionario.Guardar(clave, dato)
+	return dato
+}
+
+// ################################### PRIMITIVAS ITERADOR ###################################################
+
+func (dict *dictConcurrente[K, V]) Iterador() IterDiccionario[K, V] {
+	iter := &iteradorConcurrente[K, V]{diccionario: dict, fragmento: -1}
+	iter.avanzarFragmento()
+	return iter
+}
+
+// avanzarFragmento copia el siguiente fragmento no vacío
+func (iter *iteradorConcurrente[K, V]) avanzarFragmento() {
+	iter.pares, iter.posicion = nil, 0
+	for len(iter.pares) == 0 && iter.fragmento < len(iter.diccionario.fragmentos)-1 {
+		iter.fragmento++
+		iter.pares = iter.diccionario.fragmentos[iter.fragmento].copiar()
+	}
+}
+
+func (iter *iteradorConcurrente[K, V]) HaySiguiente() bool {
+	return iter.posicion < len(iter.pares)
+}
+
+func (iter *iteradorConcurrente[K, V]) VerActual() (K, V) {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	actual := iter.pares[iter.posicion]
+	return actual.clave, actual.dato
+}
+
+func (iter *iteradorConcurrente[K, V]) Siguiente() K {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	clave := iter.pares[iter.posicion].clave
+	iter.posicion++
+	if iter.posicion == len(iter.pares) {
+		iter.avanzarFragmento()
+	}
+	return clave
+}
@@ -0,0 +1,173 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+const GOROUTINES = 8
+
+// enParalelo corre la función en GOROUTINES goroutines, pasándole a cada una su número, y espera a que terminen.
+// La función no corre en la goroutine del test, así que debe verificar con assert y no con require
+func enParalelo(funcion func(goroutine int)) {
+	var grupo sync.WaitGroup
+	for g := 0; g < GOROUTINES; g++ {
+		grupo.Add(1)
+		go func(g int) {
+			defer grupo.Done()
+			funcion(g)
+		}(g)
+	}
+	grupo.Wait()
+}
+
+func TestConcurrenteGuardarYBorrarEnParalelo(t *testing.T) {
+	t.Log("Varias goroutines guardan, obtienen y borran claves propias a la vez, y ninguna pierde sus claves")
+	dic := TDADiccionario.CrearHashConcurrente[string, int]()
+	enParalelo(func(g int) {
+		for i := 0; i < 1000; i++ {
+			dic.Guardar(fmt.Sprintf("%d-%d", g, i), i)
+		}
+		for i := 0; i < 1000; i++ {
+			assert.EqualValues(t, i, dic.Obtener(fmt.Sprintf("%d-%d", g, i)))
+		}
+		for i := 0; i < 1000; i += 2 {
+			assert.EqualValues(t, i, dic.Borrar(fmt.Sprintf("%d-%d", g, i)))
+		}
+	})
+	require.EqualValues(t, GOROUTINES*500, dic.Cantidad())
+}
+
+func TestConcurrenteGuardarSiNoExiste(t *testing.T) {
+	t.Log("Si varias goroutines intentan guardar la misma clave con GuardarSiNoExiste, solo una lo logra")
+	dic := TDADiccionario.CrearHashConcurrente[int, int]()
+	var candado sync.Mutex
+	ganadores := make(map[int]int)
+	enParalelo(func(g int) {
+		for clave := 0; clave < 100; clave++ {
+			if dic.GuardarSiNoExiste(clave, g) {
+				candado.Lock()
+				ganadores[clave]++
+				candado.Unlock()
+			}
+		}
+	})
+	require.EqualValues(t, 100, dic.Cantidad())
+	for clave := 0; clave < 100; clave++ {
+		require.EqualValues(t, 1, ganadores[clave])
+	}
+}
+
+func TestConcurrenteObtenerOGuardar(t *testing.T) {
+	t.Log("ObtenerOGuardar devuelve a todas las goroutines el dato de la única que llegó a guardar la clave")
+	dic := TDADiccionario.CrearHashConcurrente[string, int]()
+	datos := make([]int, GOROUTINES)
+	guardados := make([]bool, GOROUTINES)
+	enParalelo(func(g int) {
+		dato, pertenecia := dic.ObtenerOGuardar("clave", g)
+		datos[g], guardados[g] = dato, !pertenecia
+	})
+	cantidadGuardados := 0
+	for g := 0; g < GOROUTINES; g++ {
+		require.EqualValues(t, dic.Obtener("clave"), datos[g])
+		if guardados[g] {
+			cantidadGuardados++
+			require.EqualValues(t, g, datos[g])
+		}
+	}
+	require.EqualValues(t, 1, cantidadGuardados)
+}
+
+func TestConcurrenteActualizar(t *testing.T) {
+	t.Log("Actualizar usado como contador desde varias goroutines no pierde incrementos")
+	dic := TDADiccionario.CrearHashConcurrente[string, int]()
+	incrementar := func(dato int, _ bool) int { return dato + 1 }
+	enParalelo(func(int) {
+		for i := 0; i < 1000; i++ {
+			dic.Actualizar(fmt.Sprintf("contador %d", i%10), incrementar)
+		}
+	})
+	for i := 0; i < 10; i++ {
+		require.EqualValues(t, GOROUTINES*100, dic.Obtener(fmt.Sprintf("contador %d", i)))
+	}
+}
+
+func TestConcurrenteIterarMientrasSeModifica(t *testing.T) {
+	t.Log("Se puede iterar, interna y externamente, mientras otras goroutines modifican el diccionario, y las " +
+		"claves que nadie modifica se ven siempre")
+	dic := TDADiccionario.CrearHashConcurrente[int, int]()
+	for i := 0; i < 500; i++ {
+		dic.Guardar(i, i)
+	}
+	enParalelo(func(g int) {
+		if g%2 == 0 {
+			for i := 0; i < 2000; i++ {
+				clave := 1000 + g*10000 + i
+				dic.Guardar(clave, clave)
+				dic.Borrar(clave)
+			}
+			return
+		}
+		fijas := 0
+		dic.Iterar(func(clave int, dato int) bool {
+			assert.EqualValues(t, clave, dato)
+			if clave < 500 {
+				fijas++
+			}
+			return true
+		})
+		assert.EqualValues(t, 500, fijas)
+		fijas = 0
+		for iter := dic.Iterador(); iter.HaySiguiente(); iter.Siguiente() {
+			if clave, _ := iter.VerActual(); clave < 500 {
+				fijas++
+			}
+		}
+		assert.EqualValues(t, 500, fijas)
+	})
+	require.EqualValues(t, 500, dic.Cantidad())
+}
+
+func TestConcurrenteIterarUsandoElDiccionario(t *testing.T) {
+	t.Log("La función de Iterar puede modificar el diccionario sin bloquearse")
+	dic := TDADiccionario.CrearHashConcurrente[int, int]()
+	for i := 0; i < 100; i++ {
+		dic.Guardar(i, i)
+	}
+	dic.Iterar(func(clave int, _ int) bool {
+		dic.Borrar(clave)
+		return true
+	})
+	require.EqualValues(t, 0, dic.Cantidad())
+}
+
+func BenchmarkConcurrente(b *testing.B) {
+	b.Log("Lecturas y escrituras en paralelo, con un único fragmento (equivalente a un candado global) y con la " +
+		"cantidad de fragmentos por defecto")
+	for _, fragmentos := range []int{1, TDADiccionario.FRAGMENTOS_POR_DEFECTO} {
+		for _, porcentajeEscrituras := range []int{0, 10, 50} {
+			nombre := fmt.Sprintf("%d fragmentos/%d%% escrituras", fragmentos, porcentajeEscrituras)
+			b.Run(nombre, func(b *testing.B) {
+				dic := TDADiccionario.CrearHashConcurrenteCon(fragmentos, TDADiccionario.Opciones[int, int]{})
+				for i := 0; i < 10000; i++ {
+					dic.Guardar(i, i)
+				}
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					for i := 0; pb.Next(); i++ {
+						clave := (i * 7919) % 10000
+						if i%100 < porcentajeEscrituras {
+							dic.Guardar(clave, i)
+						} else {
+							dic.Obtener(clave)
+						}
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package diccionario
+
+import "iter"
+
+/* El Conjunto es un Diccionario de hash (cuckoo, o la variante elegida en las opciones) con las claves como
+elementos y datos vacíos. Las operaciones entre conjuntos recorren el operando más chico y consultan al más grande,
+así su costo depende del tamaño del menor (salvo cuando el resultado contiene al mayor, y hay que copiarlo).
+*/
+
+type conjuntoImplementacion[K comparable] struct {
+	elementos Diccionario[K, struct{}]
+	opciones  Opciones[K, struct{}]
+}
+
+func CrearConjunto[K comparable]() Conjunto[K] {
+	return CrearConjuntoCon(Opciones[K, struct{}]{})
+}
+
+// CrearConjuntoCon crea un Conjunto con las opciones indicadas. Los conjuntos que devuelven sus operaciones se crean
+// con las mismas opciones
+func CrearConjuntoCon[K comparable](opciones Opciones[K, struct{}]) Conjunto[K] {
+	return &conjuntoImplementacion[K]{elementos: CrearHashCon(opciones), opciones: opciones}
+}
+
+// CrearConjuntoDe crea un Conjunto con los elementos indicados
+func CrearConjuntoDe[K comparable](elementos ...K) Conjunto[K] {
+	conjunto := CrearConjunto[K]()
+	for _, elemento := range elementos {
+		conjunto.Agregar(elemento)
+	}
+	return conjunto
+}
+
+func (conjunto *conjuntoImplementacion[K]) Agregar(elemento K) {
+	conjunto.elementos.Guardar(elemento, struct{}{})
+}
+
+func (conjunto *conjuntoImplementacion[K]) Pertenece(elemento K) bool {
+	return conjunto.elementos.Pertenece(elemento)
+}
+
+func (conjunto *conjuntoImplementacion[K]) Borrar(elemento K) {
+	if _, ok := conjunto.elementos.BorrarOk(elemento); !ok {
+		panic(ErrElementoNoPertenece.Error())
+	}
+}
+
+func (conjunto *conjuntoImplementacion[K]) Cantidad() int {
+	return conjunto.elementos.Cantidad()
+}
+
+func (conjunto *conjuntoImplementacion[K]) Iterar(visitar func(K) bool) {
+	conjunto.elementos.Iterar(func(elemento K, _ struct{}) bool { return visitar(elemento) })
+}
+
+func (conjunto *conjuntoImplementacion[K]) Elementos() iter.Seq[K] {
+	return conjunto.Iterar
+}
+
+// ################################### OPERACIONES ENTRE CONJUNTOS ############################################
+
+func (conjunto *conjuntoImplementacion[K]) vacio() *conjuntoImplementacion[K] {
+	return CrearConjuntoCon(conjunto.opciones).(*conjuntoImplementacion[K])
+}
+
+// copiar devuelve un conjunto nuevo, con las opciones de este, con los elementos del indicado
+func (conjunto *conjuntoImplementacion[K]) copiar(origen Conjunto[K]) *conjuntoImplementacion[K] {
+	copia := conjunto.vacio()
+	for elemento := range origen.Elementos() {
+		copia.Agregar(elemento)
+	}
+	return copia
+}
+
+// menorYMayor devuelve los dos conjuntos, el de menos elementos primero
+func menorYMayor[K comparable](a Conjunto[K], b Conjunto[K]) (Conjunto[K], Conjunto[K]) {
+	if a.Cantidad() <= b.Cantidad() {
+		return a, b
+	}
+	return b, a
+}
+
+func (conjunto *conjuntoImplementacion[K]) Union(otro Conjunto[K]) Conjunto[K] {
+	menor, mayor := menorYMayor[K](conjunto, otro)
+	union := conjunto.copiar(mayor)
+	for elemento := range menor.Elementos() {
+		union.Agregar(elemento)
+	}
+	return union
+}
+
+func (conjunto *conjuntoImplementacion[K]) Interseccion(otro Conjunto[K]) Conjunto[K] {
+	menor, mayor := menorYMayor[K](conjunto, otro)
+	interseccion := conjunto.vacio()
+	for elemento := range menor.Elementos() {
+		if mayor.Pertenece(elemento) {
+			interseccion.Agregar(elemento)
+		}
+	}
+	return interseccion
+}
+
+func (conjunto *conjuntoImplementacion[K]) Diferencia(otro Conjunto[K]) Conjunto[K] {
+	if otro.Cantidad() < conjunto.Cantidad() {
+		// Hay que copiar este conjunto de todas formas, así que se le sacan los elementos del otro, que son menos
+		diferencia := conjunto.copiar(conjunto)
+		for elemento := range otro.Elementos() {
+			diferencia.elementos.BorrarOk(elemento)
+		}
+		return diferencia
+	}
+	diferencia := conjunto.vacio()
+	for elemento := range conjunto.Elementos() {
+		if !otro.Pertenece(elemento) {
+			diferencia.Agregar(elemento)
+		}
+	}
+	return diferencia
+}
+
+func (conjunto *conjuntoImplementacion[K]) DiferenciaSimetrica(otro Conjunto[K]) Conjunto[K] {
+	menor, mayor := menorYMayor[K](conjunto, otro)
+	diferencia := conjunto.copiar(mayor)
+	for elemento := range menor.Elementos() {
+		if mayor.Pertenece(elemento) {
+			diferencia.elementos.Borrar(elemento)
+		} else {
+			diferencia.Agregar(elemento)
+		}
+	}
+	return diferencia
+}
+
+func (conjunto *conjuntoImplementacion[K]) EsSubconjunto(otro Conjunto[K]) bool {
+	if conjunto.Cantidad() > otro.Cantidad() {
+		return false
+	}
+	for elemento := range conjunto.Elementos() {
+		if !otro.Pertenece(elemento) {
+			return false
+		}
+	}
+	return true
+}
+
+func (conjunto *conjuntoImplementacion[K]) Igual(otro Conjunto[K]) bool {
+	return conjunto.Cantidad() == otro.Cantidad() && conjunto.EsSubconjunto(otro)
+}
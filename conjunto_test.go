@@ -0,0 +1,116 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"github.com/stretchr/testify/require"
+	"iter"
+	"slices"
+	"testing"
+)
+
+// elementosDe devuelve los elementos del conjunto ordenados, para compararlos
+func elementosDe(conjunto TDADiccionario.Conjunto[int]) []int {
+	return slices.Sorted(conjunto.Elementos())
+}
+
+func TestConjuntoPrimitivas(t *testing.T) {
+	t.Log("Agregar no repite elementos, Borrar los saca y entra en pánico si el elemento no pertenece")
+	conjunto := TDADiccionario.CrearConjunto[string]()
+	require.EqualValues(t, 0, conjunto.Cantidad())
+	conjunto.Agregar("Gato")
+	conjunto.Agregar("Perro")
+	conjunto.Agregar("Gato")
+	require.EqualValues(t, 2, conjunto.Cantidad())
+	require.True(t, conjunto.Pertenece("Gato"))
+	require.False(t, conjunto.Pertenece("Vaca"))
+	conjunto.Borrar("Gato")
+	require.False(t, conjunto.Pertenece("Gato"))
+	require.PanicsWithValue(t, "El elemento no pertenece al conjunto", func() { conjunto.Borrar("Gato") })
+	vistos := 0
+	conjunto.Iterar(func(elemento string) bool {
+		require.EqualValues(t, "Perro", elemento)
+		vistos++
+		return true
+	})
+	require.EqualValues(t, 1, vistos)
+}
+
+func TestConjuntoOperaciones(t *testing.T) {
+	t.Log("Union, Interseccion, Diferencia y DiferenciaSimetrica dan el resultado correcto en ambos sentidos, sin " +
+		"modificar a los operandos")
+	a := TDADiccionario.CrearConjuntoDe(1, 2, 3, 4, 5, 6)
+	b := TDADiccionario.CrearConjuntoDe(5, 6, 7)
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, elementosDe(a.Union(b)))
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, elementosDe(b.Union(a)))
+	require.Equal(t, []int{5, 6}, elementosDe(a.Interseccion(b)))
+	require.Equal(t, []int{5, 6}, elementosDe(b.Interseccion(a)))
+	require.Equal(t, []int{1, 2, 3, 4}, elementosDe(a.Diferencia(b)))
+	require.Equal(t, []int{7}, elementosDe(b.Diferencia(a)))
+	require.Equal(t, []int{1, 2, 3, 4, 7}, elementosDe(a.DiferenciaSimetrica(b)))
+	require.Equal(t, []int{1, 2, 3, 4, 7}, elementosDe(b.DiferenciaSimetrica(a)))
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6}, elementosDe(a))
+	require.Equal(t, []int{5, 6, 7}, elementosDe(b))
+
+	vacio := TDADiccionario.CrearConjunto[int]()
+	require.Equal(t, elementosDe(a), elementosDe(a.Union(vacio)))
+	require.EqualValues(t, 0, a.Interseccion(vacio).Cantidad())
+	require.Equal(t, elementosDe(a), elementosDe(a.Diferencia(vacio)))
+	require.EqualValues(t, 0, vacio.Diferencia(a).Cantidad())
+}
+
+func TestConjuntoSubconjuntoEIgual(t *testing.T) {
+	t.Log("EsSubconjunto e Igual comparan los elementos, sin importar el orden en que se agregaron")
+	a := TDADiccionario.CrearConjuntoDe(1, 2, 3)
+	b := TDADiccionario.CrearConjuntoDe(3, 2, 1, 0)
+	c := TDADiccionario.CrearConjuntoDe(3, 1, 2)
+	vacio := TDADiccionario.CrearConjunto[int]()
+	require.True(t, a.EsSubconjunto(b))
+	require.False(t, b.EsSubconjunto(a))
+	require.True(t, a.EsSubconjunto(c))
+	require.True(t, vacio.EsSubconjunto(a))
+	require.True(t, a.Igual(c))
+	require.True(t, c.Igual(a))
+	require.False(t, a.Igual(b))
+	require.False(t, a.Igual(TDADiccionario.CrearConjuntoDe(1, 2, 4)))
+}
+
+// conjuntoEspiado cuenta las consultas de pertenencia y los elementos recorridos de un Conjunto
+type conjuntoEspiado struct {
+	TDADiccionario.Conjunto[int]
+	consultas  int
+	recorridos int
+}
+
+func (espiado *conjuntoEspiado) Pertenece(elemento int) bool {
+	espiado.consultas++
+	return espiado.Conjunto.Pertenece(elemento)
+}
+
+func (espiado *conjuntoEspiado) Elementos() iter.Seq[int] {
+	return func(visitar func(int) bool) {
+		for elemento := range espiado.Conjunto.Elementos() {
+			espiado.recorridos++
+			if !visitar(elemento) {
+				return
+			}
+		}
+	}
+}
+
+func TestConjuntoOperacionesRecorrenElMenor(t *testing.T) {
+	t.Log("Las operaciones que no necesitan copiar al conjunto más grande solo recorren el más chico")
+	chico := TDADiccionario.CrearConjuntoDe(1, 2, 3)
+	grande := &conjuntoEspiado{Conjunto: TDADiccionario.CrearConjunto[int]()}
+	for i := 0; i < 10000; i++ {
+		grande.Agregar(i)
+	}
+	require.Equal(t, []int{1, 2, 3}, elementosDe(chico.Interseccion(grande)))
+	require.EqualValues(t, 3, grande.consultas)
+	require.EqualValues(t, 0, grande.recorridos)
+	require.EqualValues(t, 0, chico.Diferencia(grande).Cantidad())
+	require.True(t, chico.EsSubconjunto(grande))
+	require.False(t, chico.Igual(grande))
+	require.False(t, grande.Conjunto.EsSubconjunto(chico))
+	require.EqualValues(t, 9, grande.consultas)
+	require.EqualValues(t, 0, grande.recorridos)
+}
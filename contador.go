@@ -0,0 +1,126 @@
+package diccionario
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// ParContador es una clave de un Contador junto con su cuenta
+type ParContador[K comparable] struct {
+	Clave  K
+	Cuenta int
+}
+
+/* El Contador es un Diccionario de hash de claves a cuentas, que además lleva la suma de las cuentas para que Total
+sea O(1). MasComunes mantiene las n mayores cuentas vistas en un heap de mínimos de tamaño n, así recorre el
+contador una sola vez y no necesita ordenarlo entero.
+*/
+
+type contadorImplementacion[K comparable] struct {
+	cuentas  Diccionario[K, int]
+	total    int
+	opciones Opciones[K, int]
+}
+
+type heapCuentas[K comparable] []ParContador[K]
+
+func (h heapCuentas[K]) Len() int           { return len(h) }
+func (h heapCuentas[K]) Less(i, j int) bool { return h[i].Cuenta < h[j].Cuenta }
+func (h heapCuentas[K]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *heapCuentas[K]) Push(x any)        { *h = append(*h, x.(ParContador[K])) }
+
+func (h *heapCuentas[K]) Pop() any {
+	ultimo := (*h)[len(*h)-1]
+	*h = (*h)[:len(*h)-1]
+	return ultimo
+}
+
+func CrearContador[K comparable]() Contador[K] {
+	return CrearContadorCon(Opciones[K, int]{})
+}
+
+// CrearContadorCon crea un Contador cuyo Diccionario de cuentas se crea con las opciones indicadas. Los contadores
+// que devuelven Sumar y Restar se crean con las mismas opciones
+func CrearContadorCon[K comparable](opciones Opciones[K, int]) Contador[K] {
+	return &contadorImplementacion[K]{cuentas: CrearHashCon(opciones), opciones: opciones}
+}
+
+// CrearContadorDe crea un Contador con cada clave contada tantas veces como aparece en la secuencia
+func CrearContadorDe[K comparable](claves iter.Seq[K]) Contador[K] {
+	contador := CrearContador[K]()
+	for clave := range claves {
+		contador.Incrementar(clave, 1)
+	}
+	return contador
+}
+
+func (contador *contadorImplementacion[K]) Incrementar(clave K, delta int) {
+	anterior, _ := contador.cuentas.ObtenerOk(clave)
+	cuenta := anterior + delta
+	if cuenta > 0 {
+		contador.cuentas.Guardar(clave, cuenta)
+	} else {
+		cuenta = 0
+		contador.cuentas.BorrarOk(clave)
+	}
+	contador.total += cuenta - anterior
+}
+
+func (contador *contadorImplementacion[K]) Contar(clave K) int {
+	cuenta, _ := contador.cuentas.ObtenerOk(clave)
+	return cuenta
+}
+
+func (contador *contadorImplementacion[K]) Cantidad() int {
+	return contador.cuentas.Cantidad()
+}
+
+func (contador *contadorImplementacion[K]) Total() int {
+	return contador.total
+}
+
+func (contador *contadorImplementacion[K]) MasComunes(n int) []ParContador[K] {
+	if n <= 0 {
+		return []ParContador[K]{}
+	}
+	mayores := make(heapCuentas[K], 0, min(n, contador.Cantidad()))
+	for clave, cuenta := range contador.cuentas.All() {
+		if len(mayores) < n {
+			heap.Push(&mayores, ParContador[K]{clave, cuenta})
+		} else if cuenta > mayores[0].Cuenta {
+			mayores[0] = ParContador[K]{clave, cuenta}
+			heap.Fix(&mayores, 0)
+		}
+	}
+	slices.SortFunc(mayores, func(a, b ParContador[K]) int { return b.Cuenta - a.Cuenta })
+	return mayores
+}
+
+// combinar devuelve una copia del contador con las cuentas del otro incrementadas por signo
+func (contador *contadorImplementacion[K]) combinar(otro Contador[K], signo int) Contador[K] {
+	resultado := CrearContadorCon(contador.opciones)
+	for clave, cuenta := range contador.All() {
+		resultado.Incrementar(clave, cuenta)
+	}
+	for clave, cuenta := range otro.All() {
+		resultado.Incrementar(clave, signo*cuenta)
+	}
+	return resultado
+}
+
+func (contador *contadorImplementacion[K]) Sumar(otro Contador[K]) Contador[K] {
+	return contador.combinar(otro, 1)
+}
+
+func (contador *contadorImplementacion[K]) Restar(otro Contador[K]) Contador[K] {
+	return contador.combinar(otro, -1)
+}
+
+func (contador *contadorImplementacion[K]) Iterar(visitar func(K, int) bool) {
+	contador.cuentas.Iterar(visitar)
+}
+
+func (contador *contadorImplementacion[K]) All() iter.Seq2[K, int] {
+	return contador.cuentas.All()
+}
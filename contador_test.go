@@ -0,0 +1,77 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"github.com/stretchr/testify/require"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func crearContadorDePalabras(texto string) TDADiccionario.Contador[string] {
+	return TDADiccionario.CrearContadorDe(slices.Values(strings.Fields(texto)))
+}
+
+func TestContadorIncrementarYContar(t *testing.T) {
+	t.Log("Contar devuelve 0 para claves ausentes, y una clave cuya cuenta llega a cero o menos deja de estar")
+	contador := TDADiccionario.CrearContador[string]()
+	require.EqualValues(t, 0, contador.Contar("gato"))
+	contador.Incrementar("gato", 3)
+	contador.Incrementar("perro", 1)
+	contador.Incrementar("gato", 2)
+	require.EqualValues(t, 5, contador.Contar("gato"))
+	require.EqualValues(t, 6, contador.Total())
+	require.EqualValues(t, 2, contador.Cantidad())
+
+	contador.Incrementar("gato", -7)
+	require.EqualValues(t, 0, contador.Contar("gato"))
+	require.EqualValues(t, 1, contador.Total())
+	require.EqualValues(t, 1, contador.Cantidad())
+	contador.Incrementar("vaca", -1)
+	require.EqualValues(t, 0, contador.Contar("vaca"))
+	require.EqualValues(t, 1, contador.Cantidad())
+	require.Equal(t, map[string]int{"perro": 1}, maps.Collect(contador.All()))
+}
+
+func TestContadorMasComunes(t *testing.T) {
+	t.Log("MasComunes devuelve las n claves más contadas, de mayor a menor, o todas si hay menos de n")
+	contador := crearContadorDePalabras("a b c a b a d a b c e")
+	require.Equal(t, []TDADiccionario.ParContador[string]{{"a", 4}, {"b", 3}}, contador.MasComunes(2))
+	require.Equal(t, []TDADiccionario.ParContador[string]{{"a", 4}, {"b", 3}, {"c", 2}}, contador.MasComunes(3))
+	require.Len(t, contador.MasComunes(10), 5)
+	require.Empty(t, contador.MasComunes(0))
+	require.Empty(t, TDADiccionario.CrearContador[int]().MasComunes(3))
+}
+
+func TestContadorMasComunesVolumen(t *testing.T) {
+	t.Log("Con muchas claves, MasComunes coincide con ordenar todas las cuentas")
+	contador := TDADiccionario.CrearContador[int]()
+	for i := 0; i < 5000; i++ {
+		contador.Incrementar(i, (i*7919)%10007+1)
+	}
+	var cuentas []int
+	for _, cuenta := range contador.All() {
+		cuentas = append(cuentas, cuenta)
+	}
+	slices.Sort(cuentas)
+	slices.Reverse(cuentas)
+	comunes := contador.MasComunes(100)
+	require.Len(t, comunes, 100)
+	for i, par := range comunes {
+		require.EqualValues(t, cuentas[i], par.Cuenta)
+		require.EqualValues(t, par.Cuenta, contador.Contar(par.Clave))
+	}
+}
+
+func TestContadorSumarYRestar(t *testing.T) {
+	t.Log("Sumar y Restar devuelven contadores nuevos, y Restar descarta las claves sin cuenta positiva")
+	a := crearContadorDePalabras("a a a b b c")
+	b := crearContadorDePalabras("a b b b d")
+	require.Equal(t, map[string]int{"a": 4, "b": 5, "c": 1, "d": 1}, maps.Collect(a.Sumar(b).All()))
+	require.Equal(t, map[string]int{"a": 2, "c": 1}, maps.Collect(a.Restar(b).All()))
+	require.Equal(t, map[string]int{"b": 1, "d": 1}, maps.Collect(b.Restar(a).All()))
+	require.EqualValues(t, 11, a.Sumar(b).Total())
+	require.EqualValues(t, 6, a.Total())
+	require.EqualValues(t, 5, b.Total())
+}
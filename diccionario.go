@@ -1,5 +1,11 @@
 package diccionario
 
+import (
+	"hash/maphash"
+	"iter"
+	"time"
+)
+
 type Diccionario[K comparable, V any] interface {
 
 	// Guardar guarda el par clave-dato en el Diccionario. Si la clave ya se encontraba, se actualiza el dato asociado
@@ -12,10 +18,18 @@ type Diccionario[K comparable, V any] interface {
 	// 'La clave no pertenece al diccionario'
 	Obtener(clave K) V
 
+	// ObtenerOk devuelve el dato asociado a una clave y true. Si la clave no pertenece, devuelve el valor cero de V y
+	// false, sin entrar en pánico
+	ObtenerOk(clave K) (V, bool)
+
 	// Borrar borra del Diccionario la clave indicada, devolviendo el dato que se encontraba asociado. Si la clave no
 	// pertenece al diccionario, debe entrar en pánico con un mensaje 'La clave no pertenece al diccionario'
 	Borrar(clave K) V
 
+	// BorrarOk borra del Diccionario la clave indicada, devolviendo el dato que se encontraba asociado y true. Si la
+	// clave no pertenece, devuelve el valor cero de V y false, sin entrar en pánico
+	BorrarOk(clave K) (V, bool)
+
 	// Cantidad devuelve la cantidad de elementos dentro del diccionario
 	Cantidad() int
 
@@ -23,10 +37,292 @@ type Diccionario[K comparable, V any] interface {
 	// mismo
 	Iterar(func(clave K, dato V) bool)
 
+	// All devuelve una secuencia con todos los pares clave-dato del diccionario, para recorrerlo con range. Recorre los
+	// elementos en el mismo orden y con las mismas reglas que Iterar
+	All() iter.Seq2[K, V]
+
+	// Claves devuelve una secuencia con todas las claves del diccionario, para recorrerlas con range
+	Claves() iter.Seq[K]
+
+	// Valores devuelve una secuencia con todos los datos del diccionario, para recorrerlos con range
+	Valores() iter.Seq[V]
+
 	// Iterador devuelve un IterDiccionario para este Diccionario
 	Iterador() IterDiccionario[K, V]
 }
 
+// DiccionarioConcurrente es un Diccionario que puede usarse desde varias goroutines a la vez. Además de las
+// primitivas de Diccionario, ofrece operaciones compuestas que se hacen de forma atómica
+type DiccionarioConcurrente[K comparable, V any] interface {
+	Diccionario[K, V]
+
+	// GuardarSiNoExiste guarda el par clave-dato solo si la clave no pertenece al diccionario. Devuelve si lo guardó
+	GuardarSiNoExiste(clave K, dato V) bool
+
+	// ObtenerOGuardar devuelve el dato asociado a la clave y true si la clave pertenece. Si no, guarda el par
+	// clave-dato y devuelve el dato y false
+	ObtenerOGuardar(clave K, dato V) (V, bool)
+
+	// Actualizar guarda como dato de la clave el resultado de aplicar la función al dato actual. La función recibe
+	// el dato y si la clave pertenece (si no, recibe el valor cero de V y false). Devuelve el dato guardado. La
+	// función no debe usar el diccionario
+	Actualizar(clave K, actualizar func(dato V, pertenece bool) V) V
+}
+
+// DiccionarioPorInsercion es un Diccionario que recorre sus elementos en el orden en que se guardaron las
+// claves, o en el orden en que se accedió a ellas por última vez si se creó con ORDEN_ACCESO. A diferencia del
+// DiccionarioOrdenado, no compara las claves
+type DiccionarioPorInsercion[K comparable, V any] interface {
+	Diccionario[K, V]
+
+	// Primero devuelve la clave y el dato del primer elemento del recorrido. Si el diccionario está vacío, entra en
+	// pánico con un mensaje 'El diccionario esta vacio'
+	Primero() (K, V)
+
+	// Ultimo devuelve la clave y el dato del último elemento del recorrido. Si el diccionario está vacío, entra en
+	// pánico con un mensaje 'El diccionario esta vacio'
+	Ultimo() (K, V)
+}
+
+// DiccionarioOrdenado es un Diccionario que mantiene sus claves ordenadas según una función de comparación. Iterar,
+// Iterador y las secuencias recorren los elementos de menor a mayor clave
+type DiccionarioOrdenado[K comparable, V any] interface {
+	Diccionario[K, V]
+
+	// IterarRango itera internamente los elementos con claves entre desde y hasta, ambas incluidas, de menor a mayor.
+	// Si desde es nil, se itera desde la primera clave; si hasta es nil, hasta la última
+	IterarRango(desde *K, hasta *K, visitar func(clave K, dato V) bool)
+
+	// IteradorRango devuelve un IterDiccionario que recorre los elementos con claves entre desde y hasta, con las
+	// mismas reglas que IterarRango
+	IteradorRango(desde *K, hasta *K) IterDiccionario[K, V]
+
+	// Minimo devuelve la menor clave del diccionario y su dato. Si el diccionario está vacío, entra en pánico con un
+	// mensaje 'El diccionario esta vacio'
+	Minimo() (K, V)
+
+	// Maximo devuelve la mayor clave del diccionario y su dato. Si el diccionario está vacío, entra en pánico con un
+	// mensaje 'El diccionario esta vacio'
+	Maximo() (K, V)
+
+	// Piso devuelve la mayor clave del diccionario que sea menor o igual a la indicada, y su dato. Si no hay ninguna,
+	// entra en pánico con un mensaje 'No hay claves menores o iguales en el diccionario'
+	Piso(clave K) (K, V)
+
+	// Techo devuelve la menor clave del diccionario que sea mayor o igual a la indicada, y su dato. Si no hay ninguna,
+	// entra en pánico con un mensaje 'No hay claves mayores o iguales en el diccionario'
+	Techo(clave K) (K, V)
+}
+
+// CacheLRU es un Diccionario de capacidad fija: al guardar una clave nueva con la cache llena, desaloja la clave
+// usada hace más tiempo. Guardar y Obtener (u ObtenerOk) cuentan como uso de la clave; Pertenece y los recorridos no.
+// Los recorridos van desde la clave usada hace más tiempo hasta la más reciente
+type CacheLRU[K comparable, V any] interface {
+	Diccionario[K, V]
+
+	// Capacidad devuelve la cantidad máxima de elementos que guarda la cache
+	Capacidad() int
+
+	// Stats devuelve cuántas búsquedas con Obtener u ObtenerOk encontraron la clave, cuántas no, y cuántas claves se
+	// desalojaron desde que se creó la cache
+	Stats() EstadisticasCache
+}
+
+// DiccionarioConEstadisticas es un Diccionario que informa cómo se comporta su tabla de hash. Lo implementan los
+// diccionarios de CrearHashCon y CrearHashConcurrenteCon, y los de los paquetes abierto y cerrado, que solo cuentan
+// las redimensiones
+type DiccionarioConEstadisticas[K comparable, V any] interface {
+	Diccionario[K, V]
+
+	// Estadisticas devuelve el estado actual de la tabla y los contadores acumulados desde que se creó el
+	// diccionario, o desde la última llamada a ReiniciarEstadisticas
+	Estadisticas() EstadisticasHash
+
+	// ReiniciarEstadisticas pone en cero los contadores acumulados. No cambia el estado de la tabla
+	ReiniciarEstadisticas()
+}
+
+// DiccionarioConExpiracion es un Diccionario cuyas claves pueden vencer: una clave vencida se comporta como si no
+// perteneciera al diccionario. Puede usarse desde varias goroutines a la vez
+type DiccionarioConExpiracion[K comparable, V any] interface {
+	Diccionario[K, V]
+
+	// GuardarConTTL guarda el par clave-dato, que vence cuando pasa el tiempo indicado. Si el tiempo no es positivo,
+	// la clave no vence. Guardar equivale a GuardarConTTL con el TTL por defecto del diccionario
+	GuardarConTTL(clave K, dato V, ttl time.Duration)
+
+	// Limpiar borra todas las claves vencidas. No hace falta llamarla para que las claves vencidas dejen de
+	// pertenecer, solo sirve para liberar su memoria antes
+	Limpiar()
+
+	// Detener frena la limpieza periódica de claves vencidas, si el diccionario la tenía. El diccionario se puede
+	// seguir usando
+	Detener()
+}
+
+// Reloj da la hora a un DiccionarioConExpiracion, que la usa para decidir qué claves vencieron
+type Reloj interface {
+
+	// Ahora devuelve la hora actual
+	Ahora() time.Time
+}
+
+// DiccionarioPersistente es un Diccionario que anota cada Guardar y Borrar en un archivo, de donde se vuelve a cargar
+// al abrirlo. Como el Diccionario de hash, no puede usarse desde varias goroutines a la vez
+type DiccionarioPersistente[K comparable, V any] interface {
+	Diccionario[K, V]
+
+	// Compactar reescribe el archivo con un solo registro por clave, descartando los reemplazados y borrados
+	Compactar() error
+
+	// Sincronizar escribe en el disco los registros que el sistema operativo todavía tenga en memoria
+	Sincronizar() error
+
+	// Err devuelve el primer error que hubo al escribir el archivo, o nil. A partir de ese error, los cambios se
+	// siguen haciendo en memoria pero ya no se anotan en el archivo
+	Err() error
+
+	// Cerrar sincroniza y cierra el archivo, y devuelve el error de Err si lo hubo. El diccionario no debe usarse
+	// después de cerrarlo
+	Cerrar() error
+}
+
+// Conjunto es una colección de elementos sin repetidos. Las operaciones entre conjuntos devuelven un Conjunto nuevo,
+// sin modificar a ninguno de los operandos
+type Conjunto[K comparable] interface {
+
+	// Agregar agrega el elemento al conjunto. Si ya pertenecía, no hace nada
+	Agregar(elemento K)
+
+	// Pertenece determina si el elemento pertenece al conjunto
+	Pertenece(elemento K) bool
+
+	// Borrar saca el elemento del conjunto. Si no pertenece, entra en pánico con un mensaje 'El elemento no pertenece
+	// al conjunto'
+	Borrar(elemento K)
+
+	// Cantidad devuelve la cantidad de elementos del conjunto
+	Cantidad() int
+
+	// Iterar aplica la función a los elementos del conjunto, hasta que devuelva false
+	Iterar(func(elemento K) bool)
+
+	// Elementos devuelve una secuencia con los elementos del conjunto, para recorrerlos con range
+	Elementos() iter.Seq[K]
+
+	// Union devuelve un conjunto con los elementos que pertenecen a este conjunto o al otro
+	Union(otro Conjunto[K]) Conjunto[K]
+
+	// Interseccion devuelve un conjunto con los elementos que pertenecen a este conjunto y al otro
+	Interseccion(otro Conjunto[K]) Conjunto[K]
+
+	// Diferencia devuelve un conjunto con los elementos de este conjunto que no pertenecen al otro
+	Diferencia(otro Conjunto[K]) Conjunto[K]
+
+	// DiferenciaSimetrica devuelve un conjunto con los elementos que pertenecen a uno solo de los dos conjuntos
+	DiferenciaSimetrica(otro Conjunto[K]) Conjunto[K]
+
+	// EsSubconjunto determina si todos los elementos de este conjunto pertenecen al otro
+	EsSubconjunto(otro Conjunto[K]) bool
+
+	// Igual determina si los dos conjuntos tienen los mismos elementos
+	Igual(otro Conjunto[K]) bool
+}
+
+// MultiDiccionario asocia a cada clave una lista de datos, en el orden en que se guardaron. Una clave pertenece
+// mientras tenga al menos un dato
+type MultiDiccionario[K comparable, V any] interface {
+
+	// Guardar agrega el dato al final de los datos de la clave, aunque ya estuviera
+	Guardar(clave K, dato V)
+
+	// Pertenece determina si la clave tiene algún dato en el diccionario
+	Pertenece(clave K) bool
+
+	// ObtenerTodos devuelve una copia de los datos de la clave, en el orden en que se guardaron. Si la clave no
+	// pertenece, devuelve una lista vacía
+	ObtenerTodos(clave K) []V
+
+	// BorrarValor borra el primer dato de la clave que sea igual al indicado según la función iguales, y devuelve si
+	// borró alguno. Si era el único dato de la clave, la clave deja de pertenecer
+	BorrarValor(clave K, dato V, iguales func(V, V) bool) bool
+
+	// BorrarClave borra la clave con todos sus datos, y los devuelve. Si la clave no pertenece, entra en pánico con un
+	// mensaje 'La clave no pertenece al diccionario'
+	BorrarClave(clave K) []V
+
+	// CantidadClaves devuelve la cantidad de claves distintas del diccionario
+	CantidadClaves() int
+
+	// CantidadPares devuelve la cantidad total de datos, sumando los de todas las claves
+	CantidadPares() int
+
+	// Iterar aplica la función a cada par clave-dato, con los datos de cada clave juntos y en orden, hasta que
+	// devuelva false
+	Iterar(func(clave K, dato V) bool)
+
+	// All devuelve una secuencia con todos los pares clave-dato, para recorrerlos con range
+	All() iter.Seq2[K, V]
+
+	// Iterador devuelve un IterDiccionario que recorre los pares clave-dato en el mismo orden que Iterar
+	Iterador() IterDiccionario[K, V]
+}
+
+// DiccionarioBidireccional es un Diccionario en el que cada dato está asociado a una sola clave, y que permite
+// buscar tanto la clave de un dato como el dato de una clave. Qué pasa al guardar un dato que ya tiene otra clave
+// depende de la PoliticaDatosRepetidos con que se creó
+type DiccionarioBidireccional[K comparable, V comparable] interface {
+	Diccionario[K, V]
+
+	// PerteneceDato determina si el dato está asociado a alguna clave
+	PerteneceDato(dato V) bool
+
+	// ObtenerClave devuelve la clave asociada al dato. Si el dato no pertenece, entra en pánico con un mensaje
+	// 'El dato no pertenece al diccionario'
+	ObtenerClave(dato V) K
+
+	// ObtenerClaveOk devuelve la clave asociada al dato y true, o el valor cero de K y false si el dato no pertenece
+	ObtenerClaveOk(dato V) (K, bool)
+
+	// BorrarPorDato borra la clave asociada al dato, y la devuelve. Si el dato no pertenece, entra en pánico con un
+	// mensaje 'El dato no pertenece al diccionario'
+	BorrarPorDato(dato V) K
+}
+
+// Contador cuenta cuántas veces aparece cada clave. Solo guarda las claves con cuenta positiva: una clave cuya cuenta
+// llega a cero o menos deja de estar en el contador
+type Contador[K comparable] interface {
+
+	// Incrementar suma delta a la cuenta de la clave. delta puede ser negativo
+	Incrementar(clave K, delta int)
+
+	// Contar devuelve la cuenta de la clave, o 0 si la clave no está en el contador
+	Contar(clave K) int
+
+	// Cantidad devuelve la cantidad de claves distintas del contador
+	Cantidad() int
+
+	// Total devuelve la suma de las cuentas de todas las claves
+	Total() int
+
+	// MasComunes devuelve las n claves con mayor cuenta, de mayor a menor cuenta. Si hay menos de n claves, las
+	// devuelve todas. Entre claves con la misma cuenta, el orden no está definido
+	MasComunes(n int) []ParContador[K]
+
+	// Sumar devuelve un contador nuevo en el que la cuenta de cada clave es la suma de sus cuentas en ambos contadores
+	Sumar(otro Contador[K]) Contador[K]
+
+	// Restar devuelve un contador nuevo en el que la cuenta de cada clave es su cuenta en este contador menos su cuenta
+	// en el otro. Las claves que quedan sin cuenta positiva no se incluyen
+	Restar(otro Contador[K]) Contador[K]
+
+	// Iterar aplica la función a cada clave y su cuenta, hasta que devuelva false
+	Iterar(func(clave K, cuenta int) bool)
+
+	// All devuelve una secuencia con cada clave y su cuenta, para recorrerlas con range
+	All() iter.Seq2[K, int]
+}
+
 type IterDiccionario[K comparable, V any] interface {
 
 	// HaySiguiente devuelve si hay más datos para ver. Esto es, si en el lugar donde se encuentra parado
@@ -42,3 +338,20 @@ type IterDiccionario[K comparable, V any] interface {
 	// mensaje 'El iterador termino de iterar'
 	Siguiente() K
 }
+
+type Hasher[K comparable] interface {
+
+	// Hashear devuelve el hash de la clave según la función indicada por opcion (PRIMER_HASH, SEGUNDO_HASH o
+	// ULTIMO_HASH), aleatorizado con la semilla que el diccionario tiene para esa función. Para una misma clave, opción
+	// y semilla debe devolver siempre el mismo valor
+	Hashear(clave K, opcion int, semilla maphash.Seed) uint64
+}
+
+type Codec[T any] interface {
+
+	// Codificar agrega la representación binaria del dato al final de destino, y devuelve el resultado
+	Codificar(destino []byte, dato T) ([]byte, error)
+
+	// Decodificar reconstruye el dato a partir de exactamente los bytes que agregó Codificar
+	Decodificar(origen []byte) (T, error)
+}
@@ -2,25 +2,112 @@ package diccionario_test
 
 import (
 	TDADiccionario "diccionario"
+	"diccionario/abierto"
+	"diccionario/cerrado"
 	"fmt"
 	"github.com/stretchr/testify/require"
+	"hash/maphash"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 )
 
 var TAMS_VOLUMEN = []int{12500, 25000, 50000, 100000, 200000, 400000}
 
-func TestDiccionarioVacio(t *testing.T) {
+var IMPLEMENTACIONES = []string{"Cuckoo", "CuckooConBaldes", "Abierto", "Lineal", "RobinHood", "Swiss", "Concurrente", "PorInsercion", "ABB", "CacheLRU", "ConExpiracion"}
+
+// implementacionActual es la implementación que devuelve crearHash. TestDiccionario y los benchmarks la van
+// cambiando para correr las mismas pruebas sobre cada una de las IMPLEMENTACIONES
+var implementacionActual = IMPLEMENTACIONES[0]
+
+func crearHash[K comparable, V any]() TDADiccionario.Diccionario[K, V] {
+	switch implementacionActual {
+	case "CuckooConBaldes":
+		return TDADiccionario.CrearHashCon(TDADiccionario.Opciones[K, V]{Variante: TDADiccionario.CUCKOO_CON_BALDES})
+	case "Swiss":
+		return TDADiccionario.CrearHashCon(TDADiccionario.Opciones[K, V]{Variante: TDADiccionario.SWISS_TABLE})
+	case "Concurrente":
+		return TDADiccionario.CrearHashConcurrente[K, V]()
+	case "PorInsercion":
+		return TDADiccionario.CrearHashPorInsercion[K, V]()
+	case "ABB":
+		return TDADiccionario.CrearABB[K, V](compararCualquiera[K])
+	case "CacheLRU":
+		// Con capacidad de sobra para que ninguna prueba llegue a desalojar
+		return TDADiccionario.CrearCacheLRU[K, V](1 << 20)
+	case "ConExpiracion":
+		return TDADiccionario.CrearHashConExpiracion[K, V]()
+	case "Abierto":
+		return abierto.CrearHashAbierto[K, V]()
+	case "Lineal":
+		return cerrado.CrearHashLineal[K, V]()
+	case "RobinHood":
+		return cerrado.CrearHashRobinHood[K, V]()
+	default:
+		return TDADiccionario.CrearHash[K, V]()
+	}
+}
+
+// compararCualquiera ordena claves de cualquier tipo por su representación con %#v. No es el orden natural de los
+// números, pero es un orden total, que es todo lo que necesitan las PRUEBAS para usar un ABB
+func compararCualquiera[K comparable](a, b K) int {
+	return strings.Compare(fmt.Sprintf("%#v", a), fmt.Sprintf("%#v", b))
+}
+
+var PRUEBAS = []struct {
+	nombre string
+	prueba func(*testing.T)
+}{
+	{"DiccionarioVacio", pruebaDiccionarioVacio},
+	{"UnElement", pruebaUnElement},
+	{"DiccionarioGuardar", pruebaDiccionarioGuardar},
+	{"ObtenerYBorrarSinPanico", pruebaObtenerYBorrarSinPanico},
+	{"ObtenerYBorrarConError", pruebaObtenerYBorrarConError},
+	{"ReemplazoDato", pruebaReemplazoDato},
+	{"DiccionarioBorrar", pruebaDiccionarioBorrar},
+	{"ReutlizacionDeBorrados", pruebaReutlizacionDeBorrados},
+	{"GuardarYBorrarAlternado", pruebaGuardarYBorrarAlternado},
+	{"ConClavesNumericas", pruebaConClavesNumericas},
+	{"ConClavesStructs", pruebaConClavesStructs},
+	{"ClaveVacia", pruebaClaveVacia},
+	{"ValorNulo", pruebaValorNulo},
+	{"CadenaLargaParticular", pruebaCadenaLargaParticular},
+	{"IteradorInternoClaves", pruebaIteradorInternoClaves},
+	{"IteradorInternoValores", pruebaIteradorInternoValores},
+	{"IterarDiccionarioVacio", pruebaIterarDiccionarioVacio},
+	{"DiccionarioIterar", pruebaDiccionarioIterar},
+	{"IteradorNoLlegaAlFinal", pruebaIteradorNoLlegaAlFinal},
+	{"PruebaIterarTrasBorrados", pruebaPruebaIterarTrasBorrados},
+	{"RangeSobreAll", pruebaRangeSobreAll},
+	{"RangeSobreClavesYValores", pruebaRangeSobreClavesYValores},
+	{"RangeCorteTemprano", pruebaRangeCorteTemprano},
+}
+
+func TestDiccionario(t *testing.T) {
+	t.Log("Corre todas las PRUEBAS sobre cada una de las IMPLEMENTACIONES del Diccionario")
+	for _, implementacion := range IMPLEMENTACIONES {
+		implementacionActual = implementacion
+		t.Run(implementacion, func(t *testing.T) {
+			for _, prueba := range PRUEBAS {
+				t.Run(prueba.nombre, prueba.prueba)
+			}
+		})
+	}
+}
+
+func pruebaDiccionarioVacio(t *testing.T) {
 	t.Log("Comprueba que Diccionario vacio no tiene claves")
-	dic := TDADiccionario.CrearHash[string, string]()
+	dic := crearHash[string, string]()
 	require.EqualValues(t, 0, dic.Cantidad())
 	require.False(t, dic.Pertenece("A"))
 	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Obtener("A") })
 	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Borrar("A") })
 }
 
-func TestUnElement(t *testing.T) {
+func pruebaUnElement(t *testing.T) {
 	t.Log("Comprueba que Diccionario con un elemento tiene esa Clave, unicamente")
-	dic := TDADiccionario.CrearHash[string, int]()
+	dic := crearHash[string, int]()
 	dic.Guardar("A", 10)
 	require.EqualValues(t, 1, dic.Cantidad())
 	require.True(t, dic.Pertenece("A"))
@@ -29,7 +116,7 @@ func TestUnElement(t *testing.T) {
 	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Obtener("B") })
 }
 
-func TestDiccionarioGuardar(t *testing.T) {
+func pruebaDiccionarioGuardar(t *testing.T) {
 	t.Log("Guarda algunos pocos elementos en el diccionario, y se comprueba que en todo momento funciona acorde")
 	clave1 := "Gato"
 	clave2 := "Perro"
@@ -40,7 +127,7 @@ func TestDiccionarioGuardar(t *testing.T) {
 	claves := []string{clave1, clave2, clave3}
 	valores := []string{valor1, valor2, valor3}
 
-	dic := TDADiccionario.CrearHash[string, string]()
+	dic := crearHash[string, string]()
 	require.False(t, dic.Pertenece(claves[0]))
 	require.False(t, dic.Pertenece(claves[0]))
 	dic.Guardar(claves[0], valores[0])
@@ -70,11 +157,51 @@ func TestDiccionarioGuardar(t *testing.T) {
 	require.EqualValues(t, valores[2], dic.Obtener(claves[2]))
 }
 
-func TestReemplazoDato(t *testing.T) {
+func pruebaObtenerYBorrarSinPanico(t *testing.T) {
+	t.Log("Valida que ObtenerOk y BorrarOk indiquen si la clave pertenece en lugar de entrar en pánico")
+	dic := crearHash[string, int]()
+	dato, ok := dic.ObtenerOk("A")
+	require.False(t, ok)
+	require.EqualValues(t, 0, dato)
+	dato, ok = dic.BorrarOk("A")
+	require.False(t, ok)
+	require.EqualValues(t, 0, dato)
+
+	dic.Guardar("A", 10)
+	dato, ok = dic.ObtenerOk("A")
+	require.True(t, ok)
+	require.EqualValues(t, 10, dato)
+	dato, ok = dic.BorrarOk("A")
+	require.True(t, ok)
+	require.EqualValues(t, 10, dato)
+	require.EqualValues(t, 0, dic.Cantidad())
+	_, ok = dic.ObtenerOk("A")
+	require.False(t, ok)
+}
+
+func pruebaObtenerYBorrarConError(t *testing.T) {
+	t.Log("Valida que las variantes con error devuelvan ErrClaveNoPertenece cuando la clave no pertenece")
+	dic := crearHash[string, int]()
+	_, err := TDADiccionario.ObtenerConError(dic, "A")
+	require.ErrorIs(t, err, TDADiccionario.ErrClaveNoPertenece)
+	_, err = TDADiccionario.BorrarConError(dic, "A")
+	require.ErrorIs(t, err, TDADiccionario.ErrClaveNoPertenece)
+
+	dic.Guardar("A", 10)
+	dato, err := TDADiccionario.ObtenerConError(dic, "A")
+	require.NoError(t, err)
+	require.EqualValues(t, 10, dato)
+	dato, err = TDADiccionario.BorrarConError(dic, "A")
+	require.NoError(t, err)
+	require.EqualValues(t, 10, dato)
+	require.False(t, dic.Pertenece("A"))
+}
+
+func pruebaReemplazoDato(t *testing.T) {
 	t.Log("Guarda un par de claves, y luego vuelve a guardar, buscando que el dato se haya reemplazado")
 	clave := "Gato"
 	clave2 := "Perro"
-	dic := TDADiccionario.CrearHash[string, string]()
+	dic := crearHash[string, string]()
 	dic.Guardar(clave, "miau")
 	dic.Guardar(clave2, "guau")
 	require.True(t, dic.Pertenece(clave))
@@ -92,7 +219,7 @@ func TestReemplazoDato(t *testing.T) {
 	require.EqualValues(t, "baubau", dic.Obtener(clave2))
 }
 
-func TestDiccionarioBorrar(t *testing.T) {
+func pruebaDiccionarioBorrar(t *testing.T) {
 	t.Log("Guarda algunos pocos elementos en el diccionario, y se los borra, revisando que en todo momento " +
 		"el diccionario se comporte de manera adecuada")
 	clave1 := "Gato"
@@ -103,7 +230,7 @@ func TestDiccionarioBorrar(t *testing.T) {
 	valor3 := "moo"
 	claves := []string{clave1, clave2, clave3}
 	valores := []string{valor1, valor2, valor3}
-	dic := TDADiccionario.CrearHash[string, string]()
+	dic := crearHash[string, string]()
 
 	require.False(t, dic.Pertenece(claves[0]))
 	require.False(t, dic.Pertenece(claves[0]))
@@ -132,10 +259,10 @@ func TestDiccionarioBorrar(t *testing.T) {
 	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Obtener(claves[1]) })
 }
 
-func TestReutlizacionDeBorrados(t *testing.T) {
+func pruebaReutlizacionDeBorrados(t *testing.T) {
 	t.Log("Prueba de caja blanca: revisa, para el caso que fuere un HashCerrado, que no haya problema " +
 		"reinsertando un elemento borrado")
-	dic := TDADiccionario.CrearHash[string, string]()
+	dic := crearHash[string, string]()
 	clave := "hola"
 	dic.Guardar(clave, "mundo!")
 	dic.Borrar(clave)
@@ -147,9 +274,39 @@ func TestReutlizacionDeBorrados(t *testing.T) {
 	require.EqualValues(t, "mundooo!", dic.Obtener(clave))
 }
 
-func TestConClavesNumericas(t *testing.T) {
+func pruebaGuardarYBorrarAlternado(t *testing.T) {
+	t.Log("Guarda y borra muchas veces, intercalando, revisando que los borrados no hagan perder ni repetir " +
+		"claves en las búsquedas que pasan por encima de ellos")
+	dic := crearHash[int, int]()
+	for ronda := 0; ronda < 20; ronda++ {
+		for i := 0; i < 500; i++ {
+			dic.Guardar(ronda*500+i, i)
+		}
+		for i := 0; i < 500; i += 2 {
+			require.EqualValues(t, i, dic.Borrar(ronda*500+i))
+		}
+		require.EqualValues(t, (ronda+1)*250, dic.Cantidad())
+	}
+	for clave := 0; clave < 20*500; clave++ {
+		require.EqualValues(t, clave%2 == 1, dic.Pertenece(clave))
+	}
+	cantidad := 0
+	dic.Iterar(func(clave int, dato int) bool {
+		require.EqualValues(t, clave%500, dato)
+		cantidad++
+		return true
+	})
+	require.EqualValues(t, dic.Cantidad(), cantidad)
+	for clave := 1; clave < 20*500; clave += 2 {
+		dic.Borrar(clave)
+	}
+	require.EqualValues(t, 0, dic.Cantidad())
+	require.False(t, dic.Iterador().HaySiguiente())
+}
+
+func pruebaConClavesNumericas(t *testing.T) {
 	t.Log("Valida que no solo funcione con strings")
-	dic := TDADiccionario.CrearHash[int, string]()
+	dic := crearHash[int, string]()
 	clave := 10
 	valor := "Gatito"
 
@@ -161,7 +318,7 @@ func TestConClavesNumericas(t *testing.T) {
 	require.False(t, dic.Pertenece(clave))
 }
 
-func TestConClavesStructs(t *testing.T) {
+func pruebaConClavesStructs(t *testing.T) {
 	t.Log("Valida que tambien funcione con estructuras mas complejas")
 	type basico struct {
 		a string
@@ -174,7 +331,7 @@ func TestConClavesStructs(t *testing.T) {
 		z string
 	}
 
-	dic := TDADiccionario.CrearHash[avanzado, int]()
+	dic := crearHash[avanzado, int]()
 
 	a1 := avanzado{w: 10, z: "hola", x: basico{a: "mundo", b: 8}, y: basico{a: "!", b: 10}}
 	a2 := avanzado{w: 10, z: "aloh", x: basico{a: "odnum", b: 14}, y: basico{a: "!", b: 5}}
@@ -199,9 +356,9 @@ func TestConClavesStructs(t *testing.T) {
 
 }
 
-func TestClaveVacia(t *testing.T) {
+func pruebaClaveVacia(t *testing.T) {
 	t.Log("Guardamos una clave vacía (i.e. \"\") y deberia funcionar sin problemas")
-	dic := TDADiccionario.CrearHash[string, string]()
+	dic := crearHash[string, string]()
 	clave := ""
 	dic.Guardar(clave, clave)
 	require.True(t, dic.Pertenece(clave))
@@ -209,9 +366,9 @@ func TestClaveVacia(t *testing.T) {
 	require.EqualValues(t, clave, dic.Obtener(clave))
 }
 
-func TestValorNulo(t *testing.T) {
+func pruebaValorNulo(t *testing.T) {
 	t.Log("Probamos que el valor puede ser nil sin problemas")
-	dic := TDADiccionario.CrearHash[string, *int]()
+	dic := crearHash[string, *int]()
 	clave := "Pez"
 	dic.Guardar(clave, nil)
 	require.True(t, dic.Pertenece(clave))
@@ -221,14 +378,14 @@ func TestValorNulo(t *testing.T) {
 	require.False(t, dic.Pertenece(clave))
 }
 
-func TestCadenaLargaParticular(t *testing.T) {
+func pruebaCadenaLargaParticular(t *testing.T) {
 	t.Log("Se han visto casos problematicos al utilizar la funcion de hashing de K&R, por lo que " +
 		"se agrega una prueba con dicha funcion de hashing y una cadena muy larga")
 	// El caracter '~' es el de mayor valor en ASCII (126).
 	claves := make([]string, 10)
 	cadena := "%d~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~" +
 		"~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~~"
-	dic := TDADiccionario.CrearHash[string, string]()
+	dic := crearHash[string, string]()
 	valores := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J"}
 	for i := 0; i < 10; i++ {
 		claves[i] = fmt.Sprintf(cadena, i)
@@ -253,13 +410,13 @@ func buscar(clave string, claves []string) int {
 	return -1
 }
 
-func TestIteradorInternoClaves(t *testing.T) {
+func pruebaIteradorInternoClaves(t *testing.T) {
 	t.Log("Valida que todas las claves sean recorridas (y una única vez) con el iterador interno")
 	clave1 := "Gato"
 	clave2 := "Perro"
 	clave3 := "Vaca"
 	claves := []string{clave1, clave2, clave3}
-	dic := TDADiccionario.CrearHash[string, *int]()
+	dic := crearHash[string, *int]()
 	dic.Guardar(claves[0], nil)
 	dic.Guardar(claves[1], nil)
 	dic.Guardar(claves[2], nil)
@@ -283,7 +440,7 @@ func TestIteradorInternoClaves(t *testing.T) {
 	require.NotEqualValues(t, cs[2], cs[1])
 }
 
-func TestIteradorInternoValores(t *testing.T) {
+func pruebaIteradorInternoValores(t *testing.T) {
 	t.Log("Valida que los datos sean recorridas correctamente (y una única vez) con el iterador interno")
 	clave1 := "Gato"
 	clave2 := "Perro"
@@ -291,7 +448,7 @@ func TestIteradorInternoValores(t *testing.T) {
 	clave4 := "Burrito"
 	clave5 := "Hamster"
 
-	dic := TDADiccionario.CrearHash[string, int]()
+	dic := crearHash[string, int]()
 	dic.Guardar(clave1, 6)
 	dic.Guardar(clave2, 2)
 	dic.Guardar(clave3, 3)
@@ -309,7 +466,7 @@ func TestIteradorInternoValores(t *testing.T) {
 }
 
 func ejecutarPruebaVolumen(b *testing.B, n int) {
-	dic := TDADiccionario.CrearHash[string, int]()
+	dic := crearHash[string, int]()
 
 	claves := make([]string, n)
 	valores := make([]int, n)
@@ -356,25 +513,43 @@ func BenchmarkDiccionario(b *testing.B) {
 		"ejecutando muchas veces las pruebas para generar un benchmark. Valida que la cantidad " +
 		"sea la adecuada. Luego validamos que podemos obtener y ver si pertenece cada una de las claves geeneradas, " +
 		"y que luego podemos borrar sin problemas")
+	for _, implementacion := range IMPLEMENTACIONES {
+		implementacionActual = implementacion
+		for _, n := range TAMS_VOLUMEN {
+			b.Run(fmt.Sprintf("%s/Prueba %d elementos", implementacion, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					ejecutarPruebaVolumen(b, n)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSwissContraCuckoo(b *testing.B) {
+	b.Log("Compara la Swiss table con las dos variantes de cuckoo en la prueba de volumen, con las variantes de cada " +
+		"tamaño una al lado de la otra")
 	for _, n := range TAMS_VOLUMEN {
-		b.Run(fmt.Sprintf("Prueba %d elementos", n), func(b *testing.B) {
-			for i := 0; i < b.N; i++ {
-				ejecutarPruebaVolumen(b, n)
-			}
-		})
+		for _, implementacion := range []string{"Cuckoo", "CuckooConBaldes", "Swiss"} {
+			b.Run(fmt.Sprintf("Prueba %d elementos/%s", n, implementacion), func(b *testing.B) {
+				implementacionActual = implementacion
+				for i := 0; i < b.N; i++ {
+					ejecutarPruebaVolumen(b, n)
+				}
+			})
+		}
 	}
 }
 
-func TestIterarDiccionarioVacio(t *testing.T) {
+func pruebaIterarDiccionarioVacio(t *testing.T) {
 	t.Log("Iterar sobre diccionario vacio es simplemente tenerlo al final")
-	dic := TDADiccionario.CrearHash[string, int]()
+	dic := crearHash[string, int]()
 	iter := dic.Iterador()
 	require.False(t, iter.HaySiguiente())
 	require.PanicsWithValue(t, "El iterador termino de iterar", func() { iter.VerActual() })
 	require.PanicsWithValue(t, "El iterador termino de iterar", func() { iter.Siguiente() })
 }
 
-func TestDiccionarioIterar(t *testing.T) {
+func pruebaDiccionarioIterar(t *testing.T) {
 	t.Log("Guardamos 3 valores en un Diccionario, e iteramos validando que las claves sean todas diferentes " +
 		"pero pertenecientes al diccionario. Además los valores de VerActual y Siguiente van siendo correctos entre sí")
 	clave1 := "Gato"
@@ -385,7 +560,7 @@ func TestDiccionarioIterar(t *testing.T) {
 	valor3 := "moo"
 	claves := []string{clave1, clave2, clave3}
 	valores := []string{valor1, valor2, valor3}
-	dic := TDADiccionario.CrearHash[string, string]()
+	dic := crearHash[string, string]()
 	dic.Guardar(claves[0], valores[0])
 	dic.Guardar(claves[1], valores[1])
 	dic.Guardar(claves[2], valores[2])
@@ -415,9 +590,9 @@ func TestDiccionarioIterar(t *testing.T) {
 	require.PanicsWithValue(t, "El iterador termino de iterar", func() { iter.Siguiente() })
 }
 
-func TestIteradorNoLlegaAlFinal(t *testing.T) {
+func pruebaIteradorNoLlegaAlFinal(t *testing.T) {
 	t.Log("Crea un iterador y no lo avanza. Luego crea otro iterador y lo avanza.")
-	dic := TDADiccionario.CrearHash[string, string]()
+	dic := crearHash[string, string]()
 	claves := []string{"A", "B", "C"}
 	dic.Guardar(claves[0], "")
 	dic.Guardar(claves[1], "")
@@ -439,7 +614,7 @@ func TestIteradorNoLlegaAlFinal(t *testing.T) {
 	require.NotEqualValues(t, -1, buscar(tercero, claves))
 }
 
-func TestPruebaIterarTrasBorrados(t *testing.T) {
+func pruebaPruebaIterarTrasBorrados(t *testing.T) {
 	t.Log("Prueba de caja blanca: Esta prueba intenta verificar el comportamiento del hash abierto cuando " +
 		"queda con listas vacías en su tabla. El iterador debería ignorar las listas vacías, avanzando hasta " +
 		"encontrar un elemento real.")
@@ -448,7 +623,7 @@ func TestPruebaIterarTrasBorrados(t *testing.T) {
 	clave2 := "Perro"
 	clave3 := "Vaca"
 
-	dic := TDADiccionario.CrearHash[string, string]()
+	dic := crearHash[string, string]()
 	dic.Guardar(clave1, "")
 	dic.Guardar(clave2, "")
 	dic.Guardar(clave3, "")
@@ -472,7 +647,7 @@ func TestPruebaIterarTrasBorrados(t *testing.T) {
 }
 
 func ejecutarPruebasVolumenIterador(b *testing.B, n int) {
-	dic := TDADiccionario.CrearHash[string, *int]()
+	dic := crearHash[string, *int]()
 
 	claves := make([]string, n)
 	valores := make([]int, n)
@@ -530,11 +705,487 @@ func BenchmarkIterador(b *testing.B) {
 	b.Log("Prueba de stress del Iterador del Diccionario. Prueba guardando distinta cantidad de elementos " +
 		"(muy grandes) b.N elementos, iterarlos todos sin problemas. Se ejecuta cada prueba b.N veces para generar " +
 		"un benchmark")
-	for _, n := range TAMS_VOLUMEN {
-		b.Run(fmt.Sprintf("Prueba %d elementos", n), func(b *testing.B) {
-			for i := 0; i < b.N; i++ {
-				ejecutarPruebasVolumenIterador(b, n)
+	for _, implementacion := range IMPLEMENTACIONES {
+		implementacionActual = implementacion
+		for _, n := range TAMS_VOLUMEN {
+			b.Run(fmt.Sprintf("%s/Prueba %d elementos", implementacion, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					ejecutarPruebasVolumenIterador(b, n)
+				}
+			})
+		}
+	}
+}
+
+type hasherContador struct {
+	llamados *int
+}
+
+func (h hasherContador) Hashear(clave int, opcion int, _ maphash.Seed) uint64 {
+	*h.llamados++
+	return uint64(clave * opcion)
+}
+
+func TestHasherPropio(t *testing.T) {
+	t.Log("Valida que el diccionario use el hasher indicado en las opciones")
+	llamados := 0
+	dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, string]{Hasher: hasherContador{&llamados}})
+	dic.Guardar(1, "uno")
+	dic.Guardar(2, "dos")
+	require.True(t, dic.Pertenece(1))
+	require.EqualValues(t, "dos", dic.Obtener(2))
+	require.EqualValues(t, "uno", dic.Borrar(1))
+	require.False(t, dic.Pertenece(1))
+	require.Greater(t, llamados, 0)
+}
+
+func TestHashersIncorporados(t *testing.T) {
+	t.Log("Valida que los hashers incorporados funcionen con tipos definidos por el usuario")
+	type nombre string
+	type id int16
+	type uuid [16]byte
+
+	dicNombres := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[nombre, int]{
+		Hasher: TDADiccionario.HasherString[nombre]{},
+	})
+	dicIds := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[id, int]{
+		Hasher: TDADiccionario.HasherEntero[id]{},
+	})
+	dicUuids := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[uuid, int]{
+		Hasher: TDADiccionario.HasherArregloDeBytes[uuid]{},
+	})
+	for i := 0; i < 1000; i++ {
+		dicNombres.Guardar(nombre(fmt.Sprintf("%08d", i)), i)
+		dicIds.Guardar(id(-i), i)
+		dicUuids.Guardar(uuid{byte(i), byte(i >> 8), 15: 1}, i)
+	}
+	require.EqualValues(t, 1000, dicNombres.Cantidad())
+	require.EqualValues(t, 1000, dicIds.Cantidad())
+	require.EqualValues(t, 1000, dicUuids.Cantidad())
+	for i := 0; i < 1000; i++ {
+		require.EqualValues(t, i, dicNombres.Obtener(nombre(fmt.Sprintf("%08d", i))))
+		require.EqualValues(t, i, dicIds.Obtener(id(-i)))
+		require.EqualValues(t, i, dicUuids.Obtener(uuid{byte(i), byte(i >> 8), 15: 1}))
+	}
+}
+
+type hasherHostil struct {
+	primerasSemillas map[int]maphash.Seed
+	semillasVistas   map[maphash.Seed]bool
+}
+
+// Hashear hace colisionar a todas las claves mientras el diccionario use sus semillas originales
+func (h hasherHostil) Hashear(clave int, opcion int, semilla maphash.Seed) uint64 {
+	h.semillasVistas[semilla] = true
+	if _, ok := h.primerasSemillas[opcion]; !ok {
+		h.primerasSemillas[opcion] = semilla
+	}
+	if h.primerasSemillas[opcion] == semilla {
+		return 0
+	}
+	return TDADiccionario.HasherEntero[int]{}.Hashear(clave, opcion, semilla)
+}
+
+func TestSemillasPorDiccionario(t *testing.T) {
+	t.Log("Valida que cada diccionario hashee con semillas propias")
+	hasher := hasherHostil{map[int]maphash.Seed{}, map[maphash.Seed]bool{}}
+	dic1 := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{Hasher: hasher})
+	dic2 := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{Hasher: hasher})
+	dic1.Guardar(1, 1)
+	cantidad := len(hasher.semillasVistas)
+	dic2.Guardar(1, 1)
+	require.Greater(t, len(hasher.semillasVistas), cantidad)
+}
+
+func TestRehashConSemillasNuevas(t *testing.T) {
+	t.Log("Si las claves forman un ciclo de desplazamientos, el diccionario vuelve a hashear con semillas nuevas " +
+		"en lugar de perder elementos")
+	hasher := hasherHostil{map[int]maphash.Seed{}, map[maphash.Seed]bool{}}
+	dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{Hasher: hasher})
+	for i := 0; i < 10; i++ {
+		dic.Guardar(i, i*i)
+	}
+	require.Greater(t, len(hasher.semillasVistas), TDADiccionario.ULTIMO_HASH)
+	require.EqualValues(t, 10, dic.Cantidad())
+	for i := 0; i < 10; i++ {
+		require.EqualValues(t, i*i, dic.Obtener(i))
+	}
+}
+
+// hasherAcotado ubica a todas las claves, con cualquiera de las tres funciones, en las posiciones 0, 1 o 2 de la
+// tabla. Con más de tres claves los desplazamientos forman un ciclo que nunca vuelve a la clave original
+type hasherAcotado struct{}
+
+func (hasherAcotado) Hashear(clave int, opcion int, _ maphash.Seed) uint64 {
+	return uint64((clave + opcion) % 3)
+}
+
+func TestCicloDeDesplazamientosVaAlStash(t *testing.T) {
+	t.Log("Con claves armadas para formar un ciclo de desplazamientos, el guardado termina y las claves que no " +
+		"entran en la tabla quedan en el stash, donde se las sigue encontrando")
+	dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, string]{
+		Hasher:             hasherAcotado{},
+		MaxDesplazamientos: 10,
+	})
+	claves := []int{0, 1, 2, 3, 4, 5}
+	for _, clave := range claves {
+		dic.Guardar(clave, fmt.Sprintf("%d", clave))
+	}
+	require.EqualValues(t, len(claves), dic.Cantidad())
+	for _, clave := range claves {
+		require.True(t, dic.Pertenece(clave))
+		require.EqualValues(t, fmt.Sprintf("%d", clave), dic.Obtener(clave))
+	}
+
+	vistas := map[int]bool{}
+	for iter := dic.Iterador(); iter.HaySiguiente(); iter.Siguiente() {
+		clave, dato := iter.VerActual()
+		require.EqualValues(t, fmt.Sprintf("%d", clave), dato)
+		vistas[clave] = true
+	}
+	require.Len(t, vistas, len(claves))
+
+	for _, clave := range claves {
+		dic.Guardar(clave, "actualizado")
+	}
+	require.EqualValues(t, len(claves), dic.Cantidad())
+	for _, clave := range claves {
+		require.EqualValues(t, "actualizado", dic.Borrar(clave))
+		require.False(t, dic.Pertenece(clave))
+	}
+	require.EqualValues(t, 0, dic.Cantidad())
+	require.False(t, dic.Iterador().HaySiguiente())
+}
+
+func TestPocosDesplazamientos(t *testing.T) {
+	t.Log("Con un límite de desplazamientos bajo el diccionario sigue guardando todas las claves")
+	dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{MaxDesplazamientos: 4})
+	for i := 0; i < 5000; i++ {
+		dic.Guardar(i, i)
+	}
+	require.EqualValues(t, 5000, dic.Cantidad())
+	cantidad := 0
+	dic.Iterar(func(clave int, dato int) bool {
+		require.EqualValues(t, clave, dato)
+		cantidad++
+		return true
+	})
+	require.EqualValues(t, 5000, cantidad)
+	for i := 0; i < 5000; i++ {
+		require.EqualValues(t, i, dic.Borrar(i))
+	}
+	require.EqualValues(t, 0, dic.Cantidad())
+}
+
+// requireSinReservas verifica que buscar la clave y reemplazar su dato no reserven memoria
+func requireSinReservas[K comparable](t *testing.T, clave K) {
+	dic := TDADiccionario.CrearHash[K, int]()
+	dic.Guardar(clave, 1)
+	require.Zero(t, testing.AllocsPerRun(100, func() { dic.Obtener(clave) }), "Obtener con %T", clave)
+	require.Zero(t, testing.AllocsPerRun(100, func() { dic.Guardar(clave, 2) }), "Guardar con %T", clave)
+}
+
+func TestHasherSinReservasDeMemoria(t *testing.T) {
+	t.Log("Los hashers por defecto de strings, enteros y arreglos de bytes de todos los largos de ArregloDeBytes " +
+		"no deben reservar memoria al buscar una clave ni al reemplazar su dato")
+	requireSinReservas(t, "Gato")
+	requireSinReservas(t, int64(42))
+	requireSinReservas(t, [2]byte{1})
+	requireSinReservas(t, [4]byte{1})
+	requireSinReservas(t, [6]byte{1})
+	requireSinReservas(t, [8]byte{1})
+	requireSinReservas(t, [12]byte{1})
+	requireSinReservas(t, [16]byte{1})
+	requireSinReservas(t, [20]byte{1})
+	requireSinReservas(t, [32]byte{1})
+	requireSinReservas(t, [64]byte{1})
+}
+
+// hasherConstante manda a todas las claves al mismo grupo con el mismo fragmento, así cada búsqueda tiene que
+// recorrer la secuencia de sondeo completa y comparar todas las claves que encuentra
+type hasherConstante struct{}
+
+func (hasherConstante) Hashear(int, int, maphash.Seed) uint64 {
+	return 0
+}
+
+func TestSwissConColisiones(t *testing.T) {
+	t.Log("En la Swiss table, con todas las claves colisionando, se siguen encontrando las claves guardadas aunque " +
+		"se borren claves que están antes en la secuencia de sondeo")
+	dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{
+		Hasher:   hasherConstante{},
+		Variante: TDADiccionario.SWISS_TABLE,
+	})
+	for i := 0; i < 200; i++ {
+		dic.Guardar(i, i)
+	}
+	for i := 0; i < 200; i += 2 {
+		require.EqualValues(t, i, dic.Borrar(i))
+	}
+	require.EqualValues(t, 100, dic.Cantidad())
+	for i := 0; i < 200; i++ {
+		require.Equal(t, i%2 == 1, dic.Pertenece(i))
+	}
+	for i := 0; i < 200; i += 2 {
+		dic.Guardar(i, -i)
+	}
+	require.EqualValues(t, 200, dic.Cantidad())
+	for i := 1; i < 200; i += 2 {
+		require.EqualValues(t, i, dic.Obtener(i))
+	}
+}
+
+func TestCuckooConHasherConstanteFalla(t *testing.T) {
+	t.Log("Con un hasher que manda todas las claves al mismo lugar, el cuckoo deja de intentar ubicarlas " +
+		"después de una cantidad acotada de rehasheos y agrandamientos en vez de quedarse en un ciclo infinito")
+	for _, variante := range []TDADiccionario.Variante{TDADiccionario.CUCKOO, TDADiccionario.CUCKOO_CON_BALDES} {
+		dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{
+			Hasher:   hasherConstante{},
+			Variante: variante,
+		})
+		require.PanicsWithValue(t, TDADiccionario.ErrHasherDegenerado.Error(), func() {
+			for i := 0; i < 1000; i++ {
+				dic.Guardar(i, i)
+			}
+		})
+	}
+}
+
+func TestCuckooConHasherConstanteSigueUsable(t *testing.T) {
+	t.Log("Después de recuperarse del pánico por un hasher degenerado, el cuckoo tiene las claves que ya tenía, sin " +
+		"la que no pudo guardar, y se puede seguir usando")
+	for _, variante := range []TDADiccionario.Variante{TDADiccionario.CUCKOO, TDADiccionario.CUCKOO_CON_BALDES} {
+		dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{
+			Hasher:   hasherConstante{},
+			Variante: variante,
+		})
+		guardadas := 0
+		require.PanicsWithValue(t, TDADiccionario.ErrHasherDegenerado.Error(), func() {
+			for ; guardadas < 1000; guardadas++ {
+				dic.Guardar(guardadas, guardadas)
+			}
+		})
+		require.EqualValues(t, guardadas, dic.Cantidad())
+		require.False(t, dic.Pertenece(guardadas))
+		for i := 0; i < guardadas; i++ {
+			require.EqualValues(t, i, dic.Obtener(i))
+		}
+
+		dic.Guardar(0, -1)
+		require.EqualValues(t, -1, dic.Obtener(0))
+		require.EqualValues(t, guardadas-1, dic.Borrar(guardadas-1))
+		dic.Guardar(guardadas, guardadas)
+		require.EqualValues(t, guardadas, dic.Obtener(guardadas))
+		require.EqualValues(t, guardadas, dic.Cantidad())
+	}
+}
+
+var VARIANTES = []struct {
+	nombre   string
+	variante TDADiccionario.Variante
+}{
+	{"Cuckoo", TDADiccionario.CUCKOO},
+	{"CuckooConBaldes", TDADiccionario.CUCKOO_CON_BALDES},
+	{"Swiss", TDADiccionario.SWISS_TABLE},
+}
+
+var MODIFICACIONES = []struct {
+	nombre    string
+	modificar func(TDADiccionario.Diccionario[int, int])
+}{
+	{"GuardarClaveNueva", func(dic TDADiccionario.Diccionario[int, int]) { dic.Guardar(-1, -1) }},
+	{"BorrarClave", func(dic TDADiccionario.Diccionario[int, int]) { dic.Borrar(50) }},
+	{"GuardarHastaRedimensionar", func(dic TDADiccionario.Diccionario[int, int]) {
+		for i := 100; i < 10000; i++ {
+			dic.Guardar(i, i)
+		}
+	}},
+	{"BorrarHastaAchicar", func(dic TDADiccionario.Diccionario[int, int]) {
+		for i := 0; i < 100; i++ {
+			dic.Borrar(i)
+		}
+	}},
+}
+
+// crearConCien crea un diccionario de la variante con las claves 0 a 99, cada una con su mismo valor
+func crearConCien(variante TDADiccionario.Variante) TDADiccionario.Diccionario[int, int] {
+	dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{Variante: variante})
+	for i := 0; i < 100; i++ {
+		dic.Guardar(i, i)
+	}
+	return dic
+}
+
+func TestIteradorFallaSiSeModificaElDiccionario(t *testing.T) {
+	t.Log("Después de agregar o borrar claves, o de que la tabla se redimensione, todas las primitivas del " +
+		"iterador entran en pánico")
+	for _, variante := range VARIANTES {
+		for _, modificacion := range MODIFICACIONES {
+			t.Run(variante.nombre+"/"+modificacion.nombre, func(t *testing.T) {
+				dic := crearConCien(variante.variante)
+				iter := dic.Iterador()
+				iter.Siguiente()
+				modificacion.modificar(dic)
+				mensaje := TDADiccionario.ErrDiccionarioModificado.Error()
+				require.PanicsWithValue(t, mensaje, func() { iter.HaySiguiente() })
+				require.PanicsWithValue(t, mensaje, func() { iter.VerActual() })
+				require.PanicsWithValue(t, mensaje, func() { iter.Siguiente() })
+			})
+		}
+	}
+}
+
+func TestIterarFallaSiSeModificaElDiccionario(t *testing.T) {
+	t.Log("Si la función del iterador interno agrega o borra claves, Iterar entra en pánico")
+	for _, variante := range VARIANTES {
+		for _, modificacion := range MODIFICACIONES {
+			t.Run(variante.nombre+"/"+modificacion.nombre, func(t *testing.T) {
+				dic := crearConCien(variante.variante)
+				require.PanicsWithValue(t, TDADiccionario.ErrDiccionarioModificado.Error(), func() {
+					dic.Iterar(func(int, int) bool {
+						modificacion.modificar(dic)
+						return true
+					})
+				})
+			})
+		}
+	}
+}
+
+func TestIteradorSinCambiosEstructurales(t *testing.T) {
+	t.Log("Reemplazar el dato de una clave existente, o intentar borrar una clave que no pertenece, no invalida " +
+		"al iterador, que sigue recorriendo todas las claves")
+	for _, variante := range VARIANTES {
+		t.Run(variante.nombre, func(t *testing.T) {
+			dic := crearConCien(variante.variante)
+			vistas := 0
+			for iter := dic.Iterador(); iter.HaySiguiente(); vistas++ {
+				clave := iter.Siguiente()
+				dic.Guardar(clave, -clave)
+				dic.BorrarOk(-1)
+			}
+			require.EqualValues(t, 100, vistas)
+			dic.Iterar(func(clave int, dato int) bool {
+				require.EqualValues(t, -clave, dato)
+				dic.Guardar(clave, clave)
+				return true
+			})
+		})
+	}
+}
+
+func TestReemplazarEnElUmbralNoRedimensiona(t *testing.T) {
+	t.Log("Con la tabla a una clave de redimensionarse, reemplazar el dato de una clave existente no la redimensiona " +
+		"ni invalida al iterador; recién la siguiente clave nueva lo hace")
+	umbrales := []struct {
+		nombre   string
+		variante TDADiccionario.Variante
+		celdas   int
+		maxFC    float32
+	}{
+		{"Cuckoo", TDADiccionario.CUCKOO, 1, TDADiccionario.MAX_FC},
+		{"CuckooConBaldes", TDADiccionario.CUCKOO_CON_BALDES, TDADiccionario.CELDAS_POR_BALDE, TDADiccionario.MAX_FC_BALDES},
+	}
+	for _, umbral := range umbrales {
+		t.Run(umbral.nombre, func(t *testing.T) {
+			dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{Variante: umbral.variante})
+			capacidad := TDADiccionario.CAPACIDAD_INICIAL * umbral.celdas
+			// La cantidad de claves con la que la próxima clave nueva supera el factor de carga
+			cantidad := 0
+			for float32(cantidad+1)/float32(capacidad) < umbral.maxFC {
+				cantidad++
 			}
+			for i := 0; i < cantidad; i++ {
+				dic.Guardar(i, i)
+			}
+			require.EqualValues(t, capacidad, estadisticasDe(dic).Capacidad)
+
+			iter := dic.Iterador()
+			iter.Siguiente()
+			dic.Guardar(0, -1)
+			require.True(t, iter.HaySiguiente())
+			dic.Iterar(func(clave int, _ int) bool {
+				dic.Guardar(clave, clave)
+				return true
+			})
+			require.EqualValues(t, capacidad, estadisticasDe(dic).Capacidad)
+
+			dic.Guardar(-1, -1)
+			require.Greater(t, estadisticasDe(dic).Capacidad, capacidad)
+			require.PanicsWithValue(t, TDADiccionario.ErrDiccionarioModificado.Error(), func() { iter.HaySiguiente() })
 		})
 	}
 }
+
+func pruebaRangeSobreAll(t *testing.T) {
+	t.Log("Recorrer All con range visita cada par clave-dato exactamente una vez")
+	dic := crearHash[int, string]()
+	for i := 0; i < 100; i++ {
+		dic.Guardar(i, fmt.Sprintf("%d", i))
+	}
+	vistas := make(map[int]bool)
+	for clave, dato := range dic.All() {
+		require.False(t, vistas[clave])
+		require.EqualValues(t, fmt.Sprintf("%d", clave), dato)
+		vistas[clave] = true
+	}
+	require.Len(t, vistas, 100)
+}
+
+func pruebaRangeSobreClavesYValores(t *testing.T) {
+	t.Log("Claves y Valores devuelven todas las claves y todos los datos, en el mismo orden que All")
+	dic := crearHash[string, int]()
+	for i, clave := range []string{"Gato", "Perro", "Vaca", "Burrito", "Hamster"} {
+		dic.Guardar(clave, i)
+	}
+	var claves []string
+	var valores []int
+	for clave, dato := range dic.All() {
+		claves = append(claves, clave)
+		valores = append(valores, dato)
+	}
+	require.Equal(t, claves, slices.Collect(dic.Claves()))
+	require.Equal(t, valores, slices.Collect(dic.Valores()))
+	require.ElementsMatch(t, []int{0, 1, 2, 3, 4}, valores)
+}
+
+func pruebaRangeCorteTemprano(t *testing.T) {
+	t.Log("Un break dentro del range corta el recorrido, tanto en All como en Claves y Valores")
+	dic := crearHash[int, int]()
+	for i := 0; i < 50; i++ {
+		dic.Guardar(i, i)
+	}
+	vistas := 0
+	for range dic.All() {
+		vistas++
+		if vistas == 10 {
+			break
+		}
+	}
+	require.EqualValues(t, 10, vistas)
+	vistas = 0
+	for range dic.Claves() {
+		vistas++
+		break
+	}
+	for range dic.Valores() {
+		vistas++
+		break
+	}
+	require.EqualValues(t, 2, vistas)
+}
+
+func TestCrearHashDesde(t *testing.T) {
+	t.Log("CrearHashDesde y CrearHashConDesde guardan todos los pares de la secuencia, ya sea de un map o de otro " +
+		"Diccionario")
+	origen := map[string]int{"Gato": 1, "Perro": 2, "Vaca": 3}
+	dic := TDADiccionario.CrearHashDesde(maps.All(origen))
+	require.EqualValues(t, 3, dic.Cantidad())
+	for clave, dato := range origen {
+		require.EqualValues(t, dato, dic.Obtener(clave))
+	}
+	copia := TDADiccionario.CrearHashConDesde(TDADiccionario.Opciones[string, int]{
+		Variante: TDADiccionario.SWISS_TABLE,
+	}, dic.All())
+	require.Equal(t, origen, maps.Collect(copia.All()))
+}
@@ -0,0 +1,739 @@
+package disco
+
+import (
+	"bytes"
+	TDADiccionario "diccionario"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+	"os"
+	"sync"
+)
+
+const (
+	FIRMA_INDICE    = "TDAM"
+	VERSION_INDICE  = 1
+	EXTENSION_DATOS = ".datos"
+
+	CAPACIDAD_INICIAL  = 1024
+	MAX_FC             = 0.7
+	FACTOR_REDIMENSION = 2
+
+	LARGO_CABECERA = 64
+	LARGO_CELDA    = 24
+	LARGO_CRC      = 4
+)
+
+// Posiciones de los campos en la cabecera del índice
+const (
+	CAMPO_VERSION   = 4
+	CAMPO_ESTADO    = 5
+	CAMPO_CAPACIDAD = 8
+	CAMPO_CANTIDAD  = 16
+	CAMPO_BORRADOS  = 24
+	CAMPO_SAL       = 32
+)
+
+// Estados del índice: si al abrirlo no quedó CERRADO, el proceso anterior se cayó y hay que revisar las celdas
+const (
+	CERRADO byte = iota
+	ABIERTO
+)
+
+// Valores del hash de una celda que no tiene una clave. Los hashes de las claves nunca valen esto
+const (
+	VACIA uint64 = iota
+	BORRADA
+	PRIMER_HASH_VALIDO
+)
+
+const (
+	BASE_FNV  = 14695981039346656037
+	PRIMO_FNV = 1099511628211
+)
+
+// ErrMapeoNoSoportado indica que la plataforma no permite mapear archivos en memoria
+var ErrMapeoNoSoportado = errors.New("La plataforma no soporta mapear archivos en memoria")
+
+// Clave agrupa a los tipos que se pueden usar como claves: strings, que son comparables y se convierten a bytes
+type Clave interface {
+	~string
+}
+
+// Bytes agrupa a los tipos que se pueden usar como datos
+type Bytes interface {
+	~string | ~[]byte
+}
+
+// DiccionarioEnDisco es un Diccionario guardado en archivos, que no necesita entrar en memoria. Puede usarse desde
+// varias goroutines a la vez: las lecturas se hacen en paralelo, y las escrituras de a una
+type DiccionarioEnDisco[K Clave, V Bytes] interface {
+	TDADiccionario.Diccionario[K, V]
+
+	// Sincronizar escribe en el disco los cambios que el sistema operativo todavía tenga en memoria
+	Sincronizar() error
+
+	// Err devuelve el primer error de lectura o escritura de los archivos, o nil. A partir de ese error el
+	// diccionario no se modifica más, y las claves que no se pudieron leer se informan como ausentes
+	Err() error
+
+	// Cerrar sincroniza y cierra los archivos, y devuelve el error de Err si lo hubo. El diccionario no debe usarse
+	// después de cerrarlo
+	Cerrar() error
+}
+
+/* El diccionario usa dos archivos. El de datos es una región en la que solo se agregan registros, cada uno con la
+clave y el dato de un Guardar:
+
+	CRC-32 IEEE de la clave y el dato (4 bytes) | clave | dato
+
+El índice es una tabla de hash de direccionamiento abierto con sondeo lineal, como la de cerrado.CrearHashLineal,
+mapeada en memoria. Empieza con una cabecera de LARGO_CABECERA bytes:
+
+	FIRMA_INDICE | versión (1 byte) | estado (1 byte) | relleno | capacidad | cantidad | borrados | sal
+
+con los números en 8 bytes little endian, y sigue con la capacidad (una potencia de 2) de celdas de LARGO_CELDA bytes:
+
+	hash de la clave (8 bytes) | posición del registro (8 bytes) | largo de la clave (4 bytes) | largo del dato (4 bytes)
+
+El hash es FNV-1a con la sal del índice, porque tiene que ser el mismo en todas las ejecuciones. Guardar agrega
+primero el registro y recién después publica la celda que lo apunta, así los lectores nunca ven una celda sin su
+registro, y agregar el registro no bloquea a los lectores. Reemplazar un dato agrega un registro nuevo; el anterior
+queda sin usar en el archivo de datos.
+
+Si el proceso se cae, al abrir el índice de nuevo su estado sigue en ABIERTO, y se revisan todas las celdas: las
+que apuntan fuera del archivo de datos, a un registro con un CRC que no coincide, o a una clave de otro hash, se
+borran. Redimensionar arma un índice nuevo al lado del original y lo renombra sobre él, así una caída deja alguno
+de los dos completo.
+*/
+
+type hashEnDisco[K Clave, V Bytes] struct {
+	ruta string
+
+	// candado protege al índice: los lectores lo toman para leer, y los escritores solo para publicar sus cambios
+	candado   sync.RWMutex
+	archivo   *os.File
+	indice    []byte
+	capacidad int
+	cantidad  int
+	borrados  int
+	sal       uint64
+
+	// redimensiones invalida a los iteradores, que recorren las celdas por posición
+	redimensiones int
+
+	// escritor hace que las escrituras sean de a una, y protege al final del archivo de datos
+	escritor sync.Mutex
+	datos    *os.File
+	finDatos int64
+	registro []byte
+
+	err        error
+	candadoErr sync.Mutex
+}
+
+type celda struct {
+	hash       uint64
+	posicion   int64
+	largoClave int
+	largoDato  int
+}
+
+type iteradorEnDisco[K Clave, V Bytes] struct {
+	dict          *hashEnDisco[K, V]
+	posicion      int
+	clave         K
+	dato          V
+	redimensiones int
+	haySiguiente  bool
+}
+
+// AbrirHashEnDisco abre el diccionario cuyo índice está en la ruta, y sus datos en la ruta con EXTENSION_DATOS,
+// creándolos si no existen. Los archivos no deben abrirse desde dos procesos a la vez
+func AbrirHashEnDisco[K Clave, V Bytes](ruta string) (DiccionarioEnDisco[K, V], error) {
+	dict := &hashEnDisco[K, V]{ruta: ruta}
+	var err error
+	if dict.datos, err = os.OpenFile(ruta+EXTENSION_DATOS, os.O_RDWR|os.O_CREATE, 0o644); err != nil {
+		return nil, err
+	}
+	info, err := dict.datos.Stat()
+	if err != nil {
+		dict.datos.Close()
+		return nil, err
+	}
+	dict.finDatos = info.Size()
+	if err := dict.abrirIndice(); err != nil {
+		dict.datos.Close()
+		return nil, fmt.Errorf("%s: %w", ruta, err)
+	}
+	return dict, nil
+}
+
+func (dict *hashEnDisco[K, V]) abrirIndice() error {
+	archivo, err := os.OpenFile(dict.ruta, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := archivo.Stat()
+	if err == nil && info.Size() == 0 {
+		err = crearIndice(archivo, CAPACIDAD_INICIAL, rand.Uint64())
+	}
+	var indice []byte
+	if err == nil {
+		indice, err = leerIndice(archivo)
+	}
+	if err != nil {
+		archivo.Close()
+		return err
+	}
+	dict.archivo, dict.indice = archivo, indice
+	dict.capacidad = int(binary.LittleEndian.Uint64(indice[CAMPO_CAPACIDAD:]))
+	dict.cantidad = int(binary.LittleEndian.Uint64(indice[CAMPO_CANTIDAD:]))
+	dict.borrados = int(binary.LittleEndian.Uint64(indice[CAMPO_BORRADOS:]))
+	dict.sal = binary.LittleEndian.Uint64(indice[CAMPO_SAL:])
+	if indice[CAMPO_ESTADO] != CERRADO {
+		dict.recuperar()
+	}
+	indice[CAMPO_ESTADO] = ABIERTO
+	return nil
+}
+
+// crearIndice escribe en el archivo la cabecera de un índice vacío y le da el tamaño de sus celdas
+func crearIndice(archivo *os.File, capacidad int, sal uint64) error {
+	cabecera := make([]byte, LARGO_CABECERA)
+	copy(cabecera, FIRMA_INDICE)
+	cabecera[CAMPO_VERSION] = VERSION_INDICE
+	cabecera[CAMPO_ESTADO] = CERRADO
+	binary.LittleEndian.PutUint64(cabecera[CAMPO_CAPACIDAD:], uint64(capacidad))
+	binary.LittleEndian.PutUint64(cabecera[CAMPO_SAL:], sal)
+	if _, err := archivo.WriteAt(cabecera, 0); err != nil {
+		return err
+	}
+	return archivo.Truncate(int64(LARGO_CABECERA + capacidad*LARGO_CELDA))
+}
+
+// leerIndice valida la cabecera del archivo y lo mapea en memoria
+func leerIndice(archivo *os.File) ([]byte, error) {
+	cabecera := make([]byte, LARGO_CABECERA)
+	if _, err := archivo.ReadAt(cabecera, 0); err != nil || string(cabecera[:len(FIRMA_INDICE)]) != FIRMA_INDICE {
+		return nil, TDADiccionario.ErrFormatoInvalido
+	}
+	if version := cabecera[CAMPO_VERSION]; version != VERSION_INDICE {
+		return nil, fmt.Errorf("%w: %d", TDADiccionario.ErrVersionNoSoportada, version)
+	}
+	capacidad := binary.LittleEndian.Uint64(cabecera[CAMPO_CAPACIDAD:])
+	info, err := archivo.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if capacidad == 0 || bits.OnesCount64(capacidad) != 1 || info.Size() != int64(LARGO_CABECERA+capacidad*LARGO_CELDA) {
+		return nil, TDADiccionario.ErrFormatoInvalido
+	}
+	return mapear(archivo, int(info.Size()))
+}
+
+// recuperar borra las celdas que no apuntan a un registro válido, y vuelve a contar las claves
+func (dict *hashEnDisco[K, V]) recuperar() {
+	dict.cantidad, dict.borrados = 0, 0
+	for i := 0; i < dict.capacidad; i++ {
+		actual := dict.celda(i)
+		switch {
+		case actual.hash == VACIA:
+			continue
+		case actual.hash != BORRADA && dict.registroValido(actual):
+			dict.cantidad++
+			continue
+		}
+		dict.escribirCelda(i, celda{hash: BORRADA})
+		dict.borrados++
+	}
+}
+
+func (dict *hashEnDisco[K, V]) registroValido(actual celda) bool {
+	largo := int64(LARGO_CRC + actual.largoClave + actual.largoDato)
+	if actual.posicion < 0 || actual.posicion > dict.finDatos-largo {
+		return false
+	}
+	registro := make([]byte, largo)
+	if _, err := dict.datos.ReadAt(registro, actual.posicion); err != nil {
+		return false
+	}
+	contenido := registro[LARGO_CRC:]
+	return crc32.ChecksumIEEE(contenido) == binary.LittleEndian.Uint32(registro) &&
+		hashear(dict.sal, contenido[:actual.largoClave]) == actual.hash
+}
+
+// ######################################### CELDAS #########################################################
+
+func hashear[T ~string | ~[]byte](sal uint64, clave T) uint64 {
+	hash := uint64(BASE_FNV) ^ sal
+	for i := 0; i < len(clave); i++ {
+		hash ^= uint64(clave[i])
+		hash *= PRIMO_FNV
+	}
+	// FNV mezcla mal los últimos bytes en los bits bajos, que son los que eligen la posición
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	if hash < PRIMER_HASH_VALIDO {
+		hash += PRIMER_HASH_VALIDO
+	}
+	return hash
+}
+
+func (dict *hashEnDisco[K, V]) celda(posicion int) celda {
+	bytes := dict.indice[LARGO_CABECERA+posicion*LARGO_CELDA:]
+	return celda{
+		hash:       binary.LittleEndian.Uint64(bytes),
+		posicion:   int64(binary.LittleEndian.Uint64(bytes[8:])),
+		largoClave: int(binary.LittleEndian.Uint32(bytes[16:])),
+		largoDato:  int(binary.LittleEndian.Uint32(bytes[20:])),
+	}
+}
+
+func escribirCelda(indice []byte, posicion int, nueva celda) {
+	bytes := indice[LARGO_CABECERA+posicion*LARGO_CELDA:]
+	binary.LittleEndian.PutUint64(bytes, nueva.hash)
+	binary.LittleEndian.PutUint64(bytes[8:], uint64(nueva.posicion))
+	binary.LittleEndian.PutUint32(bytes[16:], uint32(nueva.largoClave))
+	binary.LittleEndian.PutUint32(bytes[20:], uint32(nueva.largoDato))
+}
+
+func (dict *hashEnDisco[K, V]) escribirCelda(posicion int, nueva celda) {
+	escribirCelda(dict.indice, posicion, nueva)
+}
+
+// buscar devuelve la posición de la clave y true si pertenece. Si no, devuelve la posición donde guardarla, como
+// en cerrado.CrearHashLineal. Se llama con el candado tomado
+func (dict *hashEnDisco[K, V]) buscar(clave K, hash uint64) (int, bool, error) {
+	primerBorrada := -1
+	posicion := int(hash & uint64(dict.capacidad-1))
+	for {
+		actual := dict.celda(posicion)
+		if actual.hash == VACIA {
+			break
+		}
+		if actual.hash == hash && actual.largoClave == len(clave) {
+			iguales, err := dict.claveEn(actual, clave)
+			if err != nil {
+				return 0, false, err
+			}
+			if iguales {
+				return posicion, true, nil
+			}
+		}
+		if actual.hash == BORRADA && primerBorrada == -1 {
+			primerBorrada = posicion
+		}
+		posicion = (posicion + 1) & (dict.capacidad - 1)
+	}
+	if primerBorrada != -1 {
+		return primerBorrada, false, nil
+	}
+	return posicion, false, nil
+}
+
+func (dict *hashEnDisco[K, V]) claveEn(actual celda, clave K) (bool, error) {
+	leida := make([]byte, actual.largoClave)
+	if _, err := dict.datos.ReadAt(leida, actual.posicion+LARGO_CRC); err != nil {
+		return false, err
+	}
+	return string(leida) == string(clave), nil
+}
+
+func (dict *hashEnDisco[K, V]) leer(actual celda) (K, V, error) {
+	registro := make([]byte, actual.largoClave+actual.largoDato)
+	if _, err := dict.datos.ReadAt(registro, actual.posicion+LARGO_CRC); err != nil {
+		return "", V(registro[:0]), err
+	}
+	return K(registro[:actual.largoClave]), V(registro[actual.largoClave:]), nil
+}
+
+// ######################################### ERRORES ########################################################
+
+func (dict *hashEnDisco[K, V]) fallar(err error) {
+	dict.candadoErr.Lock()
+	defer dict.candadoErr.Unlock()
+	if dict.err == nil {
+		dict.err = err
+	}
+}
+
+func (dict *hashEnDisco[K, V]) Err() error {
+	dict.candadoErr.Lock()
+	defer dict.candadoErr.Unlock()
+	return dict.err
+}
+
+// ####################################### ESCRITURA ########################################################
+
+// agregarRegistro escribe el registro al final del archivo de datos y devuelve su celda. Se llama con el candado de
+// escritor tomado, pero no el del índice
+func (dict *hashEnDisco[K, V]) agregarRegistro(clave K, dato V) (celda, error) {
+	dict.registro = append(dict.registro[:0], make([]byte, LARGO_CRC)...)
+	dict.registro = append(dict.registro, clave...)
+	dict.registro = append(dict.registro, dato...)
+	binary.LittleEndian.PutUint32(dict.registro, crc32.ChecksumIEEE(dict.registro[LARGO_CRC:]))
+	if _, err := dict.datos.WriteAt(dict.registro, dict.finDatos); err != nil {
+		return celda{}, err
+	}
+	nueva := celda{
+		hash:       hashear(dict.sal, clave),
+		posicion:   dict.finDatos,
+		largoClave: len(clave),
+		largoDato:  len(dato),
+	}
+	dict.finDatos += int64(len(dict.registro))
+	return nueva, nil
+}
+
+// redimensionar arma un índice nuevo con la capacidad indicada y lo renombra sobre el actual. Las celdas guardan el
+// hash de su clave, así que no hace falta leer el archivo de datos. Se llama con el candado del índice tomado
+func (dict *hashEnDisco[K, V]) redimensionar(capacidad int) error {
+	temporal := dict.ruta + ".redimensionando"
+	archivo, err := os.OpenFile(temporal, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	var indice []byte
+	err = crearIndice(archivo, capacidad, dict.sal)
+	if err == nil {
+		indice, err = leerIndice(archivo)
+	}
+	if err != nil {
+		archivo.Close()
+		os.Remove(temporal)
+		return err
+	}
+
+	for i := 0; i < dict.capacidad; i++ {
+		actual := dict.celda(i)
+		if actual.hash < PRIMER_HASH_VALIDO {
+			continue
+		}
+		posicion := int(actual.hash & uint64(capacidad-1))
+		for binary.LittleEndian.Uint64(indice[LARGO_CABECERA+posicion*LARGO_CELDA:]) != VACIA {
+			posicion = (posicion + 1) & (capacidad - 1)
+		}
+		escribirCelda(indice, posicion, actual)
+	}
+	binary.LittleEndian.PutUint64(indice[CAMPO_CANTIDAD:], uint64(dict.cantidad))
+	indice[CAMPO_ESTADO] = ABIERTO
+
+	// El índice nuevo tiene que estar completo en el disco antes de reemplazar al anterior
+	if err := dict.datos.Sync(); err == nil {
+		err = sincronizarMapa(indice)
+	}
+	if err == nil {
+		err = os.Rename(temporal, dict.ruta)
+	}
+	if err != nil {
+		desmapear(indice)
+		archivo.Close()
+		os.Remove(temporal)
+		return err
+	}
+	desmapear(dict.indice)
+	dict.archivo.Close()
+	dict.archivo, dict.indice = archivo, indice
+	dict.capacidad, dict.borrados = capacidad, 0
+	dict.redimensiones++
+	return nil
+}
+
+func (dict *hashEnDisco[K, V]) Guardar(clave K, dato V) {
+	dict.escritor.Lock()
+	defer dict.escritor.Unlock()
+	if dict.Err() != nil {
+		return
+	}
+	nueva, err := dict.agregarRegistro(clave, dato)
+	if err != nil {
+		dict.fallar(err)
+		return
+	}
+
+	dict.candado.Lock()
+	defer dict.candado.Unlock()
+	posicion, pertenece, err := dict.buscar(clave, nueva.hash)
+	if err != nil {
+		dict.fallar(err)
+		return
+	}
+	if pertenece {
+		dict.escribirCelda(posicion, nueva)
+		return
+	}
+
+	// Las celdas borradas también alargan los sondeos, así que cuentan para la carga, como en el hash lineal
+	if float32(dict.cantidad+dict.borrados+1)/float32(dict.capacidad) > MAX_FC {
+		capacidad := dict.capacidad
+		if float32(dict.cantidad+1)/float32(dict.capacidad) > MAX_FC/FACTOR_REDIMENSION {
+			capacidad *= FACTOR_REDIMENSION
+		}
+		if err := dict.redimensionar(capacidad); err != nil {
+			dict.fallar(err)
+			return
+		}
+		// La tabla nueva no tiene celdas borradas, así que la clave va en la primera vacía de su recorrido
+		if posicion, _, err = dict.buscar(clave, nueva.hash); err != nil {
+			dict.fallar(err)
+			return
+		}
+	}
+	if dict.celda(posicion).hash == BORRADA {
+		dict.borrados--
+	}
+	dict.cantidad++
+	dict.escribirCelda(posicion, nueva)
+}
+
+func (dict *hashEnDisco[K, V]) Borrar(clave K) V {
+	dato, ok := dict.BorrarOk(clave)
+	if !ok {
+		panic(TDADiccionario.ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (dict *hashEnDisco[K, V]) BorrarOk(clave K) (V, bool) {
+	dict.escritor.Lock()
+	defer dict.escritor.Unlock()
+	dict.candado.Lock()
+	defer dict.candado.Unlock()
+	var cero V
+	if dict.Err() != nil {
+		return cero, false
+	}
+	posicion, pertenece, err := dict.buscar(clave, hashear(dict.sal, clave))
+	if err != nil {
+		dict.fallar(err)
+		return cero, false
+	}
+	if !pertenece {
+		return cero, false
+	}
+	_, dato, err := dict.leer(dict.celda(posicion))
+	if err != nil {
+		dict.fallar(err)
+		return cero, false
+	}
+	dict.escribirCelda(posicion, celda{hash: BORRADA})
+	dict.cantidad--
+	dict.borrados++
+	return dato, true
+}
+
+// sincronizar se llama con los candados de escritor y del índice tomados
+func (dict *hashEnDisco[K, V]) sincronizar() error {
+	binary.LittleEndian.PutUint64(dict.indice[CAMPO_CANTIDAD:], uint64(dict.cantidad))
+	binary.LittleEndian.PutUint64(dict.indice[CAMPO_BORRADOS:], uint64(dict.borrados))
+	if err := dict.datos.Sync(); err != nil {
+		return err
+	}
+	return sincronizarMapa(dict.indice)
+}
+
+func (dict *hashEnDisco[K, V]) Sincronizar() error {
+	dict.escritor.Lock()
+	defer dict.escritor.Unlock()
+	dict.candado.Lock()
+	defer dict.candado.Unlock()
+	if err := dict.Err(); err != nil {
+		return err
+	}
+	if err := dict.sincronizar(); err != nil {
+		dict.fallar(err)
+	}
+	return dict.Err()
+}
+
+// Cerrar deja el índice en estado CERRADO, así al volver a abrirlo no hace falta revisar sus celdas
+func (dict *hashEnDisco[K, V]) Cerrar() error {
+	dict.escritor.Lock()
+	defer dict.escritor.Unlock()
+	dict.candado.Lock()
+	defer dict.candado.Unlock()
+	if dict.indice == nil {
+		return dict.errCerrado()
+	}
+	if dict.Err() == nil {
+		if err := dict.sincronizar(); err != nil {
+			dict.fallar(err)
+		} else {
+			dict.indice[CAMPO_ESTADO] = CERRADO
+			if err := sincronizarMapa(dict.indice); err != nil {
+				dict.fallar(err)
+			}
+		}
+	}
+	for _, err := range []error{desmapear(dict.indice), dict.archivo.Close(), dict.datos.Close()} {
+		if err != nil {
+			dict.fallar(err)
+		}
+	}
+	dict.indice = nil
+	dict.fallar(os.ErrClosed)
+	return dict.errCerrado()
+}
+
+// errCerrado devuelve el error de Err, salvo que sea el de haber cerrado el diccionario
+func (dict *hashEnDisco[K, V]) errCerrado() error {
+	if err := dict.Err(); !errors.Is(err, os.ErrClosed) {
+		return err
+	}
+	return nil
+}
+
+// ####################################### LECTURA ##########################################################
+
+func (dict *hashEnDisco[K, V]) Pertenece(clave K) bool {
+	_, ok := dict.ObtenerOk(clave)
+	return ok
+}
+
+func (dict *hashEnDisco[K, V]) Obtener(clave K) V {
+	dato, ok := dict.ObtenerOk(clave)
+	if !ok {
+		panic(TDADiccionario.ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+// ObtenerOk devuelve una copia del dato, leída del archivo
+func (dict *hashEnDisco[K, V]) ObtenerOk(clave K) (V, bool) {
+	dict.candado.RLock()
+	defer dict.candado.RUnlock()
+	var cero V
+	if dict.indice == nil {
+		return cero, false
+	}
+	posicion, pertenece, err := dict.buscar(clave, hashear(dict.sal, clave))
+	if err == nil && pertenece {
+		var dato V
+		if _, dato, err = dict.leer(dict.celda(posicion)); err == nil {
+			return dato, true
+		}
+	}
+	if err != nil {
+		dict.fallar(err)
+	}
+	return cero, false
+}
+
+func (dict *hashEnDisco[K, V]) Cantidad() int {
+	dict.candado.RLock()
+	defer dict.candado.RUnlock()
+	return dict.cantidad
+}
+
+// siguienteOcupada devuelve la posición y el par de la primera celda ocupada desde la posición indicada, y false si
+// no hay más. Entra en pánico si el índice se redimensionó desde que empezó el recorrido
+func (dict *hashEnDisco[K, V]) siguienteOcupada(desde int, redimensiones int) (int, K, V, bool) {
+	dict.candado.RLock()
+	defer dict.candado.RUnlock()
+	if dict.redimensiones != redimensiones {
+		panic(TDADiccionario.ErrDiccionarioModificado.Error())
+	}
+	for ; dict.indice != nil && desde < dict.capacidad; desde++ {
+		actual := dict.celda(desde)
+		if actual.hash < PRIMER_HASH_VALIDO {
+			continue
+		}
+		clave, dato, err := dict.leer(actual)
+		if err != nil {
+			dict.fallar(err)
+			break
+		}
+		return desde, clave, dato, true
+	}
+	var dato V
+	return desde, "", dato, false
+}
+
+func (dict *hashEnDisco[K, V]) redimensionesActuales() int {
+	dict.candado.RLock()
+	defer dict.candado.RUnlock()
+	return dict.redimensiones
+}
+
+// Iterar no bloquea el diccionario mientras visita cada par, así la función puede usarlo, y otras goroutines
+// pueden modificarlo. Los cambios que no redimensionan el índice pueden verse o no, según en qué parte del recorrido
+// ocurran; si el índice se redimensiona, Iterar entra en pánico como los demás diccionarios
+func (dict *hashEnDisco[K, V]) Iterar(visitar func(K, V) bool) {
+	redimensiones := dict.redimensionesActuales()
+	posicion, clave, dato, ok := dict.siguienteOcupada(0, redimensiones)
+	for ok && visitar(clave, dato) {
+		posicion, clave, dato, ok = dict.siguienteOcupada(posicion+1, redimensiones)
+	}
+}
+
+func (dict *hashEnDisco[K, V]) All() iter.Seq2[K, V] {
+	return dict.Iterar
+}
+
+func (dict *hashEnDisco[K, V]) Claves() iter.Seq[K] {
+	return func(visitar func(K) bool) {
+		dict.Iterar(func(clave K, _ V) bool { return visitar(clave) })
+	}
+}
+
+func (dict *hashEnDisco[K, V]) Valores() iter.Seq[V] {
+	return func(visitar func(V) bool) {
+		dict.Iterar(func(_ K, dato V) bool { return visitar(dato) })
+	}
+}
+
+// MarshalJSON escribe los pares como un objeto JSON, como los diccionarios de TDADiccionario. Si hubo un error de
+// lectura del archivo, lo devuelve en lugar de un objeto incompleto
+func (dict *hashEnDisco[K, V]) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := TDADiccionario.CodificarJSON[K, V](&buffer, dict, false); err != nil {
+		return nil, err
+	}
+	if err := dict.Err(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (dict *hashEnDisco[K, V]) UnmarshalJSON(datos []byte) error {
+	if err := TDADiccionario.DecodificarJSON[K, V](bytes.NewReader(datos), dict); err != nil {
+		return err
+	}
+	return dict.Err()
+}
+
+// ################################### PRIMITIVAS ITERADOR ###################################################
+
+// Iterador, como Iterar, ve o no los cambios hechos mientras se recorre, y entra en pánico si el índice se
+// redimensiona
+func (dict *hashEnDisco[K, V]) Iterador() TDADiccionario.IterDiccionario[K, V] {
+	iter := &iteradorEnDisco[K, V]{dict: dict, redimensiones: dict.redimensionesActuales()}
+	iter.posicion, iter.clave, iter.dato, iter.haySiguiente = dict.siguienteOcupada(0, iter.redimensiones)
+	return iter
+}
+
+func (iter *iteradorEnDisco[K, V]) HaySiguiente() bool {
+	return iter.haySiguiente
+}
+
+func (iter *iteradorEnDisco[K, V]) VerActual() (K, V) {
+	if !iter.HaySiguiente() {
+		panic(TDADiccionario.ErrIteradorTerminado.Error())
+	}
+	return iter.clave, iter.dato
+}
+
+func (iter *iteradorEnDisco[K, V]) Siguiente() K {
+	if !iter.HaySiguiente() {
+		panic(TDADiccionario.ErrIteradorTerminado.Error())
+	}
+	clave := iter.clave
+	iter.posicion, iter.clave, iter.dato, iter.haySiguiente = iter.dict.siguienteOcupada(iter.posicion+1, iter.redimensiones)
+	return clave
+}
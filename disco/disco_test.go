@@ -0,0 +1,223 @@
+package disco_test
+
+import (
+	TDADiccionario "diccionario"
+	"diccionario/disco"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func abrirEnDisco(t *testing.T, ruta string) disco.DiccionarioEnDisco[string, []byte] {
+	dic, err := disco.AbrirHashEnDisco[string, []byte](ruta)
+	require.NoError(t, err)
+	return dic
+}
+
+func TestEnDiscoOperacionesYReapertura(t *testing.T) {
+	t.Log("El diccionario en disco guarda, reemplaza y borra claves, y al volver a abrirlo tiene lo mismo, aunque " +
+		"el índice se haya redimensionado varias veces")
+	ruta := filepath.Join(t.TempDir(), "tabla")
+	dic := abrirEnDisco(t, ruta)
+	const cantidad = 5 * disco.CAPACIDAD_INICIAL
+	for i := 0; i < cantidad; i++ {
+		dic.Guardar(fmt.Sprint(i), []byte(fmt.Sprintf("dato %d", i)))
+	}
+	for i := 0; i < cantidad; i += 3 {
+		require.EqualValues(t, fmt.Sprintf("dato %d", i), dic.Borrar(fmt.Sprint(i)))
+	}
+	dic.Guardar("1", []byte("reemplazado"))
+	dic.Guardar("", []byte{})
+	require.False(t, dic.Pertenece("0"))
+	require.Panics(t, func() { dic.Obtener("0") })
+	require.NoError(t, dic.Err())
+	require.NoError(t, dic.Cerrar())
+
+	dic = abrirEnDisco(t, ruta)
+	defer dic.Cerrar()
+	require.EqualValues(t, cantidad-(cantidad+2)/3+1, dic.Cantidad())
+	require.EqualValues(t, "reemplazado", dic.Obtener("1"))
+	require.EqualValues(t, "dato 2", dic.Obtener("2"))
+	require.True(t, dic.Pertenece(""))
+	require.False(t, dic.Pertenece("3"))
+	vistos := 0
+	for clave, dato := range dic.All() {
+		if clave != "1" && clave != "" {
+			require.EqualValues(t, "dato "+clave, dato)
+		}
+		vistos++
+	}
+	require.EqualValues(t, dic.Cantidad(), vistos)
+}
+
+func TestEnDiscoCopiaLosDatos(t *testing.T) {
+	t.Log("Guardar copia el dato al archivo, y Obtener devuelve una copia, así se pueden modificar sin afectar al " +
+		"diccionario")
+	dic := abrirEnDisco(t, filepath.Join(t.TempDir(), "tabla"))
+	defer dic.Cerrar()
+	dato := []byte("original")
+	dic.Guardar("clave", dato)
+	dato[0] = 'X'
+	obtenido := dic.Obtener("clave")
+	require.EqualValues(t, "original", obtenido)
+	obtenido[0] = 'Y'
+	require.EqualValues(t, "original", dic.Obtener("clave"))
+
+	conStrings, err := disco.AbrirHashEnDisco[string, string](filepath.Join(t.TempDir(), "strings"))
+	require.NoError(t, err)
+	defer conStrings.Cerrar()
+	conStrings.Guardar("hola", "mundo")
+	require.EqualValues(t, "mundo", conStrings.Obtener("hola"))
+}
+
+func TestEnDiscoRecuperaTrasCaida(t *testing.T) {
+	t.Log("Si el proceso se cae sin cerrar el diccionario, al abrirlo se descartan las claves cuyos registros no " +
+		"llegaron completos al archivo de datos, y el diccionario se puede seguir usando")
+	ruta := filepath.Join(t.TempDir(), "tabla")
+	// El diccionario que "se cae" se cierra recién al terminar el test, solo para liberar el mapeo y los archivos
+	caido := abrirEnDisco(t, ruta)
+	t.Cleanup(func() { caido.Cerrar() })
+	caido.Guardar("a", []byte("uno"))
+	caido.Guardar("b", []byte("dos"))
+	require.NoError(t, caido.Sincronizar())
+	info, err := os.Stat(ruta + disco.EXTENSION_DATOS)
+	require.NoError(t, err)
+	caido.Guardar("c", []byte("tres"))
+	caido.Guardar("d", []byte("cuatro"))
+	require.NoError(t, os.Truncate(ruta+disco.EXTENSION_DATOS, info.Size()+5))
+
+	dic := abrirEnDisco(t, ruta)
+	require.EqualValues(t, 2, dic.Cantidad())
+	require.EqualValues(t, "dos", dic.Obtener("b"))
+	require.False(t, dic.Pertenece("c"))
+	require.False(t, dic.Pertenece("d"))
+	dic.Guardar("e", []byte("cinco"))
+	require.NoError(t, dic.Cerrar())
+
+	dic = abrirEnDisco(t, ruta)
+	defer dic.Cerrar()
+	require.EqualValues(t, 3, dic.Cantidad())
+	require.EqualValues(t, "uno", dic.Obtener("a"))
+	require.EqualValues(t, "cinco", dic.Obtener("e"))
+}
+
+func TestEnDiscoLectoresConcurrentes(t *testing.T) {
+	t.Log("Varias goroutines pueden leer mientras otra escribe, incluso mientras el índice se redimensiona")
+	dic := abrirEnDisco(t, filepath.Join(t.TempDir(), "tabla"))
+	defer dic.Cerrar()
+	for i := 0; i < 100; i++ {
+		dic.Guardar(fmt.Sprintf("fija %d", i), []byte(fmt.Sprint(i)))
+	}
+
+	var lectores sync.WaitGroup
+	terminar := make(chan struct{})
+	for l := 0; l < 4; l++ {
+		lectores.Add(1)
+		go func() {
+			defer lectores.Done()
+			for i := 0; ; i = (i + 1) % 100 {
+				select {
+				case <-terminar:
+					return
+				default:
+				}
+				assert.EqualValues(t, fmt.Sprint(i), dic.Obtener(fmt.Sprintf("fija %d", i)))
+			}
+		}()
+	}
+	for i := 0; i < 3*disco.CAPACIDAD_INICIAL; i++ {
+		dic.Guardar(fmt.Sprintf("nueva %d", i), []byte("x"))
+	}
+	close(terminar)
+	lectores.Wait()
+	require.NoError(t, dic.Err())
+	require.EqualValues(t, 100+3*disco.CAPACIDAD_INICIAL, dic.Cantidad())
+}
+
+func TestEnDiscoIterador(t *testing.T) {
+	t.Log("El iterador recorre todas las claves, y entra en pánico si el índice se redimensiona durante el recorrido")
+	dic := abrirEnDisco(t, filepath.Join(t.TempDir(), "tabla"))
+	defer dic.Cerrar()
+	for i := 0; i < 10; i++ {
+		dic.Guardar(fmt.Sprint(i), []byte(fmt.Sprint(i)))
+	}
+	vistas := map[string]bool{}
+	for iter := dic.Iterador(); iter.HaySiguiente(); iter.Siguiente() {
+		clave, dato := iter.VerActual()
+		require.EqualValues(t, clave, dato)
+		vistas[clave] = true
+	}
+	require.Len(t, vistas, 10)
+
+	iter := dic.Iterador()
+	require.PanicsWithValue(t, TDADiccionario.ErrDiccionarioModificado.Error(), func() {
+		for i := 0; i < disco.CAPACIDAD_INICIAL; i++ {
+			dic.Guardar(fmt.Sprintf("nueva %d", i), nil)
+		}
+		iter.Siguiente()
+	})
+}
+
+func TestEnDiscoReemplazarNoRedimensiona(t *testing.T) {
+	t.Log("Con el índice lleno justo hasta el límite de carga, reemplazar el dato de una clave que ya está no lo " +
+		"redimensiona, así un iterador sigue siendo válido; una clave nueva sí lo redimensiona")
+	dic := abrirEnDisco(t, filepath.Join(t.TempDir(), "tabla"))
+	defer dic.Cerrar()
+	limite := 0
+	for float32(limite+1)/disco.CAPACIDAD_INICIAL <= disco.MAX_FC {
+		limite++
+	}
+	for i := 0; i < limite; i++ {
+		dic.Guardar(fmt.Sprint(i), nil)
+	}
+	iter := dic.Iterador()
+	for i := 0; i < limite; i++ {
+		dic.Guardar(fmt.Sprint(i), []byte("reemplazado"))
+	}
+	require.NotPanics(t, func() { iter.Siguiente() })
+	require.NoError(t, dic.Err())
+	require.EqualValues(t, limite, dic.Cantidad())
+
+	dic.Guardar("nueva", nil)
+	require.PanicsWithValue(t, TDADiccionario.ErrDiccionarioModificado.Error(), func() { iter.Siguiente() })
+	require.EqualValues(t, limite+1, dic.Cantidad())
+}
+
+func TestEnDiscoJSON(t *testing.T) {
+	t.Log("El diccionario en disco se escribe como un objeto JSON con sus pares y se vuelve a cargar con json.Unmarshal")
+	dic, err := disco.AbrirHashEnDisco[string, string](filepath.Join(t.TempDir(), "tabla"))
+	require.NoError(t, err)
+	defer dic.Cerrar()
+	dic.Guardar("hola", "mundo")
+	dic.Guardar("chau", "todos")
+	datos, err := json.Marshal(dic)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"hola": "mundo", "chau": "todos"}`, string(datos))
+
+	copia, err := disco.AbrirHashEnDisco[string, string](filepath.Join(t.TempDir(), "copia"))
+	require.NoError(t, err)
+	defer copia.Cerrar()
+	require.NoError(t, json.Unmarshal(datos, copia))
+	require.EqualValues(t, 2, copia.Cantidad())
+	require.EqualValues(t, "todos", copia.Obtener("chau"))
+}
+
+func TestEnDiscoArchivoInvalido(t *testing.T) {
+	t.Log("Abrir un índice con otra firma devuelve un error, y después de cerrar el diccionario Err lo informa")
+	ruta := filepath.Join(t.TempDir(), "otro")
+	require.NoError(t, os.WriteFile(ruta, make([]byte, 100), 0o644))
+	_, err := disco.AbrirHashEnDisco[string, string](ruta)
+	require.ErrorIs(t, err, TDADiccionario.ErrFormatoInvalido)
+
+	dic := abrirEnDisco(t, filepath.Join(t.TempDir(), "tabla"))
+	require.NoError(t, dic.Cerrar())
+	require.NoError(t, dic.Cerrar())
+	dic.Guardar("a", nil)
+	require.False(t, dic.Pertenece("a"))
+	require.ErrorIs(t, dic.Err(), os.ErrClosed)
+}
@@ -0,0 +1,17 @@
+//go:build !(linux || darwin || freebsd || dragonfly)
+
+package disco
+
+import "os"
+
+func mapear(*os.File, int) ([]byte, error) {
+	return nil, ErrMapeoNoSoportado
+}
+
+func desmapear([]byte) error {
+	return ErrMapeoNoSoportado
+}
+
+func sincronizarMapa([]byte) error {
+	return ErrMapeoNoSoportado
+}
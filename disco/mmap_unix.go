@@ -0,0 +1,26 @@
+//go:build linux || darwin || freebsd || dragonfly
+
+package disco
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func mapear(archivo *os.File, tamanio int) ([]byte, error) {
+	return syscall.Mmap(int(archivo.Fd()), 0, tamanio, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+}
+
+func desmapear(mapa []byte) error {
+	return syscall.Munmap(mapa)
+}
+
+// sincronizarMapa espera a que los cambios hechos en la memoria mapeada estén escritos en el archivo
+func sincronizarMapa(mapa []byte) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_MSYNC, uintptr(unsafe.Pointer(&mapa[0])), uintptr(len(mapa)), syscall.MS_SYNC)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
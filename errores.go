@@ -0,0 +1,75 @@
+package diccionario
+
+import "errors"
+
+var (
+	// ErrClaveNoPertenece indica que se buscó o borró una clave que no pertenece al diccionario
+	ErrClaveNoPertenece = errors.New("La clave no pertenece al diccionario")
+
+	// ErrDatoNoPertenece indica que se buscó o borró por un dato que no pertenece al diccionario bidireccional
+	ErrDatoNoPertenece = errors.New("El dato no pertenece al diccionario")
+
+	// ErrDatoRepetido indica que se guardó en un diccionario bidireccional un dato que ya está asociado a otra clave
+	ErrDatoRepetido = errors.New("El dato ya esta asociado a otra clave")
+
+	// ErrFormatoInvalido indica que los datos que se quisieron cargar no son un diccionario serializado
+	ErrFormatoInvalido = errors.New("Los datos no tienen el formato de un diccionario serializado")
+
+	// ErrVersionNoSoportada indica que los datos se serializaron con una versión del formato que no se sabe leer
+	ErrVersionNoSoportada = errors.New("La version del diccionario serializado no esta soportada")
+
+	// ErrDatosCorruptos indica que la suma de verificación de un diccionario serializado no coincide con sus datos
+	ErrDatosCorruptos = errors.New("Los datos del diccionario serializado estan corruptos")
+
+	// ErrClaveJSONNoSoportada indica que el tipo de las claves no es string, entero ni encoding.TextMarshaler, por lo
+	// que no se pueden usar como nombres de un objeto JSON
+	ErrClaveJSONNoSoportada = errors.New("El tipo de las claves no se puede representar en JSON")
+
+	// ErrElementoNoPertenece indica que se borró un elemento que no pertenece al conjunto
+	ErrElementoNoPertenece = errors.New("El elemento no pertenece al conjunto")
+
+	// ErrIteradorTerminado indica que se usó un iterador que ya recorrió todos los elementos
+	ErrIteradorTerminado = errors.New("El iterador termino de iterar")
+
+	// ErrDiccionarioModificado indica que se usó un iterador después de que se agregaran o borraran claves del
+	// diccionario que recorre, o de que este se redimensionara
+	ErrDiccionarioModificado = errors.New("El diccionario fue modificado durante la iteracion")
+
+	// ErrDiccionarioVacio indica que se pidió un elemento en particular (el primero, el mínimo, etc.) a un
+	// diccionario vacío
+	ErrDiccionarioVacio = errors.New("El diccionario esta vacio")
+
+	// ErrSinPiso indica que se pidió el piso de una clave menor a todas las del diccionario
+	ErrSinPiso = errors.New("No hay claves menores o iguales en el diccionario")
+
+	// ErrSinTecho indica que se pidió el techo de una clave mayor a todas las del diccionario
+	ErrSinTecho = errors.New("No hay claves mayores o iguales en el diccionario")
+
+	// ErrHasherDegenerado indica que el hasher manda tantas claves a las mismas posiciones que, aun con semillas
+	// nuevas y una tabla más grande, no hay forma de ubicarlas. Después del pánico, el diccionario tiene las claves
+	// que tenía antes del Guardar que falló
+	ErrHasherDegenerado = errors.New("El hasher no distribuye las claves en la tabla")
+
+	// ErrCapacidadInvalida indica que se quiso crear una estructura de capacidad fija con capacidad menor a uno
+	ErrCapacidadInvalida = errors.New("La capacidad debe ser mayor a cero")
+)
+
+// ObtenerConError devuelve el dato asociado a la clave, o ErrClaveNoPertenece si la clave no pertenece al
+// diccionario
+func ObtenerConError[K comparable, V any](dic Diccionario[K, V], clave K) (V, error) {
+	dato, ok := dic.ObtenerOk(clave)
+	if !ok {
+		return dato, ErrClaveNoPertenece
+	}
+	return dato, nil
+}
+
+// BorrarConError borra la clave del diccionario y devuelve el dato que tenía asociado, o ErrClaveNoPertenece si la
+// clave no pertenece al diccionario
+func BorrarConError[K comparable, V any](dic Diccionario[K, V], clave K) (V, error) {
+	dato, ok := dic.BorrarOk(clave)
+	if !ok {
+		return dato, ErrClaveNoPertenece
+	}
+	return dato, nil
+}
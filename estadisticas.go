@@ -0,0 +1,137 @@
+package diccionario
+
+// EstadisticasHash describe el estado de la tabla de un DiccionarioConEstadisticas. Capacidad, Elementos,
+// FactorDeCarga, PorFuncion y EnStash son el estado actual; el resto son contadores que se pueden reiniciar
+type EstadisticasHash struct {
+
+	// Capacidad es la cantidad de celdas de la tabla, contando todas las de cada balde o grupo
+	Capacidad int
+
+	// Elementos es la cantidad de claves guardadas, incluidas las del stash
+	Elementos int
+
+	// FactorDeCarga es Elementos sobre Capacidad
+	FactorDeCarga float64
+
+	// Agrandamientos y Achicamientos cuentan las redimensiones de la tabla
+	Agrandamientos int
+	Achicamientos  int
+
+	// Rehasheos cuenta, en los cuckoo, las veces que no hubo lugar para un elemento y se volvió a armar la tabla con
+	// semillas nuevas. En la SWISS_TABLE cuenta las veces que se volvió a armar del mismo tamaño para descartar las
+	// celdas borradas
+	Rehasheos int
+
+	// Desplazamientos es la cantidad total de elementos que desplazó el cuckoo para hacer lugar a otros, y
+	// MaxDesplazamientos la mayor cantidad desplazada para un mismo guardado. En la SWISS_TABLE son siempre cero
+	Desplazamientos    int
+	MaxDesplazamientos int
+
+	// PorFuncion cuenta los elementos de la tabla ubicados con cada función de hash: PorFuncion[0] con PRIMER_HASH,
+	// y así. Una búsqueda de una clave ubicada con la función i calcula i funciones de hash. En la SWISS_TABLE, que
+	// usa una sola función, son siempre cero
+	PorFuncion [ULTIMO_HASH]int
+
+	// EnStash es la cantidad de elementos que quedaron fuera de la tabla, en el stash
+	EnStash int
+}
+
+// contadoresHash son los contadores de EstadisticasHash que se acumulan y se reinician
+type contadoresHash struct {
+	agrandamientos     int
+	achicamientos      int
+	rehasheos          int
+	desplazamientos    int
+	maxDesplazamientos int
+}
+
+func (contadores *contadoresHash) redimensionado(anterior, nueva int) {
+	if nueva > anterior {
+		contadores.agrandamientos++
+	} else if nueva < anterior {
+		contadores.achicamientos++
+	}
+}
+
+func (contadores *contadoresHash) desplazados(cantidad int) {
+	contadores.desplazamientos += cantidad
+	contadores.maxDesplazamientos = max(contadores.maxDesplazamientos, cantidad)
+}
+
+func (contadores contadoresHash) estadisticas(capacidad, elementos int) EstadisticasHash {
+	return EstadisticasHash{
+		Capacidad:          capacidad,
+		Elementos:          elementos,
+		FactorDeCarga:      float64(elementos) / float64(capacidad),
+		Agrandamientos:     contadores.agrandamientos,
+		Achicamientos:      contadores.achicamientos,
+		Rehasheos:          contadores.rehasheos,
+		Desplazamientos:    contadores.desplazamientos,
+		MaxDesplazamientos: contadores.maxDesplazamientos,
+	}
+}
+
+// ####################################### CUCKOO ###########################################################
+
+// Estadisticas recorre la tabla para contar los elementos ubicados con cada función de hash
+func (dict *dictImplementacion[K, V]) Estadisticas() EstadisticasHash {
+	estadisticas := dict.contadores.estadisticas(len(dict.tabla), dict.elementos)
+	for _, elemento := range dict.tabla {
+		if elemento != nil {
+			estadisticas.PorFuncion[elemento.opcion-PRIMER_HASH]++
+		}
+	}
+	estadisticas.EnStash = len(dict.stash)
+	return estadisticas
+}
+
+func (dict *dictImplementacion[K, V]) ReiniciarEstadisticas() {
+	dict.contadores = contadoresHash{}
+}
+
+// ######################################## SWISS ###########################################################
+
+func (dict *dictSwiss[K, V]) Estadisticas() EstadisticasHash {
+	return dict.contadores.estadisticas(dict.capacidad(), dict.elementos)
+}
+
+func (dict *dictSwiss[K, V]) ReiniciarEstadisticas() {
+	dict.contadores = contadoresHash{}
+}
+
+// ##################################### CONCURRENTE ########################################################
+
+// Estadisticas suma las de los fragmentos, salvo MaxDesplazamientos, que es el mayor de todos. Como Cantidad, lee
+// los fragmentos de a uno
+func (dict *dictConcurrente[K, V]) Estadisticas() EstadisticasHash {
+	var total EstadisticasHash
+	for i := range dict.fragmentos {
+		fragmento := &dict.fragmentos[i]
+		fragmento.RLock()
+		estadisticas := fragmento.diccionario.(DiccionarioConEstadisticas[K, V]).Estadisticas()
+		fragmento.RUnlock()
+
+		total.Capacidad += estadisticas.Capacidad
+		total.Elementos += estadisticas.Elementos
+		total.Agrandamientos += estadisticas.Agrandamientos
+		total.Achicamientos += estadisticas.Achicamientos
+		total.Rehasheos += estadisticas.Rehasheos
+		total.Desplazamientos += estadisticas.Desplazamientos
+		total.MaxDesplazamientos = max(total.MaxDesplazamientos, estadisticas.MaxDesplazamientos)
+		for funcion, cantidad := range estadisticas.PorFuncion {
+			total.PorFuncion[funcion] += cantidad
+		}
+		total.EnStash += estadisticas.EnStash
+	}
+	total.FactorDeCarga = float64(total.Elementos) / float64(total.Capacidad)
+	return total
+}
+
+func (dict *dictConcurrente[K, V]) ReiniciarEstadisticas() {
+	for i := range dict.fragmentos {
+		fragmento := &dict.fragmentos[i]
+		fragmento.Lock()
+		fragmento.diccionario.(DiccionarioConEstadisticas[K, V]).ReiniciarEstadisticas()
+		fragmento.Unlock()
+	}
+}
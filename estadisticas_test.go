@@ -0,0 +1,155 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"diccionario/abierto"
+	"diccionario/cerrado"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func estadisticasDe[K comparable, V any](dic TDADiccionario.Diccionario[K, V]) TDADiccionario.EstadisticasHash {
+	return dic.(TDADiccionario.DiccionarioConEstadisticas[K, V]).Estadisticas()
+}
+
+func requireEstadoConsistente(t *testing.T, estadisticas TDADiccionario.EstadisticasHash, elementos int) {
+	require.EqualValues(t, elementos, estadisticas.Elementos)
+	require.InDelta(t, float64(elementos)/float64(estadisticas.Capacidad), estadisticas.FactorDeCarga, 1e-9)
+	ubicados := estadisticas.EnStash
+	for _, cantidad := range estadisticas.PorFuncion {
+		ubicados += cantidad
+	}
+	require.EqualValues(t, elementos, ubicados)
+}
+
+func TestEstadisticasCuckoo(t *testing.T) {
+	t.Log("Las estadísticas del cuckoo cuentan las redimensiones y los desplazamientos, ubican cada elemento con " +
+		"una función de hash o en el stash, y al reiniciarlas se ponen en cero los contadores pero no el estado")
+	for nombre, variante := range map[string]TDADiccionario.Variante{
+		"Cuckoo":          TDADiccionario.CUCKOO,
+		"CuckooConBaldes": TDADiccionario.CUCKOO_CON_BALDES,
+	} {
+		t.Run(nombre, func(t *testing.T) {
+			dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{Variante: variante})
+			inicial := estadisticasDe(dic)
+			require.EqualValues(t, 0, inicial.FactorDeCarga)
+			require.Zero(t, inicial.Agrandamientos)
+
+			for i := 0; i < 20000; i++ {
+				dic.Guardar(i, i)
+			}
+			estadisticas := estadisticasDe(dic)
+			requireEstadoConsistente(t, estadisticas, 20000)
+			require.Greater(t, estadisticas.Agrandamientos, 0)
+			require.Zero(t, estadisticas.Achicamientos)
+			require.Greater(t, estadisticas.MaxDesplazamientos, 0)
+			require.GreaterOrEqual(t, estadisticas.Desplazamientos, estadisticas.MaxDesplazamientos)
+			require.Greater(t, estadisticas.PorFuncion[0], estadisticas.PorFuncion[2])
+
+			for i := 0; i < 19900; i++ {
+				dic.Borrar(i)
+			}
+			estadisticas = estadisticasDe(dic)
+			requireEstadoConsistente(t, estadisticas, 100)
+			require.Greater(t, estadisticas.Achicamientos, 0)
+
+			dic.(TDADiccionario.DiccionarioConEstadisticas[int, int]).ReiniciarEstadisticas()
+			reiniciadas := estadisticasDe(dic)
+			require.Zero(t, reiniciadas.Agrandamientos)
+			require.Zero(t, reiniciadas.Achicamientos)
+			require.Zero(t, reiniciadas.Rehasheos)
+			require.Zero(t, reiniciadas.Desplazamientos)
+			require.Zero(t, reiniciadas.MaxDesplazamientos)
+			require.EqualValues(t, estadisticas.Capacidad, reiniciadas.Capacidad)
+			require.EqualValues(t, estadisticas.PorFuncion, reiniciadas.PorFuncion)
+		})
+	}
+}
+
+func TestEstadisticasRehasheos(t *testing.T) {
+	t.Log("Sin stash y con un solo desplazamiento permitido, cada guardado que no encuentra lugar cuenta un rehasheo")
+	dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{
+		MaxDesplazamientos: 1,
+		CapacidadStash:     -1,
+	})
+	for i := 0; i < 1000; i++ {
+		dic.Guardar(i, i)
+	}
+	estadisticas := estadisticasDe(dic)
+	requireEstadoConsistente(t, estadisticas, 1000)
+	require.Greater(t, estadisticas.Rehasheos, 0)
+	require.EqualValues(t, 1, estadisticas.MaxDesplazamientos)
+	require.Zero(t, estadisticas.EnStash)
+}
+
+func TestEstadisticasSwiss(t *testing.T) {
+	t.Log("La SWISS_TABLE cuenta sus redimensiones, y no tiene desplazamientos ni funciones de hash alternativas")
+	dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{Variante: TDADiccionario.SWISS_TABLE})
+	for i := 0; i < 10000; i++ {
+		dic.Guardar(i, i)
+	}
+	for i := 0; i < 9990; i++ {
+		dic.Borrar(i)
+	}
+	estadisticas := estadisticasDe(dic)
+	require.EqualValues(t, 10, estadisticas.Elementos)
+	require.Greater(t, estadisticas.Agrandamientos, 0)
+	require.Greater(t, estadisticas.Achicamientos, 0)
+	require.Zero(t, estadisticas.Desplazamientos)
+	require.EqualValues(t, [TDADiccionario.ULTIMO_HASH]int{}, estadisticas.PorFuncion)
+}
+
+func TestEstadisticasConcurrente(t *testing.T) {
+	t.Log("Las estadísticas del diccionario concurrente suman las de sus fragmentos")
+	dic := TDADiccionario.CrearHashConcurrenteCon(4, TDADiccionario.Opciones[int, int]{})
+	for i := 0; i < 5000; i++ {
+		dic.Guardar(i, i)
+	}
+	estadisticas := estadisticasDe[int, int](dic)
+	requireEstadoConsistente(t, estadisticas, 5000)
+	require.GreaterOrEqual(t, estadisticas.Agrandamientos, 4)
+	require.GreaterOrEqual(t, estadisticas.Capacidad, 4*TDADiccionario.CAPACIDAD_INICIAL)
+
+	dic.(TDADiccionario.DiccionarioConEstadisticas[int, int]).ReiniciarEstadisticas()
+	require.Zero(t, estadisticasDe[int, int](dic).Agrandamientos)
+}
+
+// llenarHastaAgrandar guarda claves nuevas en el diccionario mientras entren sin agrandar la tabla, y devuelve
+// cuántas guardó
+func llenarHastaAgrandar(crear func() TDADiccionario.Diccionario[int, int]) int {
+	referencia := crear()
+	for i := 0; ; i++ {
+		referencia.Guardar(i, i)
+		if estadisticasDe(referencia).Agrandamientos > 0 {
+			return i
+		}
+	}
+}
+
+func TestEstadisticasReemplazarNoAgranda(t *testing.T) {
+	t.Log("Con la tabla llena justo hasta el límite de carga, reemplazar el dato de una clave que ya está no la " +
+		"agranda: solo una clave nueva lo hace")
+	constructores := map[string]func() TDADiccionario.Diccionario[int, int]{
+		"Abierto":   abierto.CrearHashAbierto[int, int],
+		"Lineal":    cerrado.CrearHashLineal[int, int],
+		"RobinHood": cerrado.CrearHashRobinHood[int, int],
+	}
+	for nombre, crear := range constructores {
+		t.Run(nombre, func(t *testing.T) {
+			limite := llenarHastaAgrandar(crear)
+			dic := crear()
+			for i := 0; i < limite; i++ {
+				dic.Guardar(i, i)
+			}
+			for i := 0; i < limite; i++ {
+				dic.Guardar(i, -i)
+			}
+			require.EqualValues(t, 0, estadisticasDe(dic).Agrandamientos)
+			require.EqualValues(t, -(limite - 1), dic.Obtener(limite-1))
+
+			dic.Guardar(limite, limite)
+			require.EqualValues(t, 1, estadisticasDe(dic).Agrandamientos)
+			require.EqualValues(t, limite+1, dic.Cantidad())
+		})
+	}
+}
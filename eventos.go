@@ -0,0 +1,75 @@
+package diccionario
+
+/* Los eventos se llaman de forma sincrónica, desde la goroutine que modifica el diccionario, al final de Guardar y
+Borrar, cuando la tabla ya quedó en su estado final: AlRedimensionar se llama una sola vez por operación, aunque la
+tabla se haya reconstruido varias veces, y antes que AlGuardar, o después de AlBorrar. La función puede consultar o
+volver a modificar el diccionario. Si no hay ninguno registrado, el costo es el de comparar la función con nil.
+
+Con CrearHashConcurrenteCon cada fragmento llama a los eventos con su candado tomado, así que pueden llamarse desde
+varias goroutines a la vez, AlRedimensionar informa la capacidad del fragmento, y la función no debe usar el
+diccionario concurrente: si la clave cae en el mismo fragmento, la goroutine se bloquea para siempre.
+*/
+
+type eventosHash[K comparable, V any] struct {
+	alGuardar       func(clave K, viejo, nuevo V, existia bool)
+	alBorrar        func(clave K, dato V)
+	alRedimensionar func(capacidadVieja, capacidadNueva int)
+
+	// redimension guarda lo que informó el Diccionario de hash interno de un envoltorio, hasta que el envoltorio
+	// termina su operación
+	redimension struct {
+		vieja, nueva int
+		pendiente    bool
+	}
+}
+
+func crearEventos[K comparable, V any](opciones Opciones[K, V]) eventosHash[K, V] {
+	return eventosHash[K, V]{
+		alGuardar:       opciones.AlGuardar,
+		alBorrar:        opciones.AlBorrar,
+		alRedimensionar: opciones.AlRedimensionar,
+	}
+}
+
+func (eventos *eventosHash[K, V]) guardado(clave K, viejo, nuevo V, existia bool) {
+	if eventos.alGuardar != nil {
+		eventos.alGuardar(clave, viejo, nuevo, existia)
+	}
+}
+
+func (eventos *eventosHash[K, V]) borrado(clave K, dato V) {
+	if eventos.alBorrar != nil {
+		eventos.alBorrar(clave, dato)
+	}
+}
+
+func (eventos *eventosHash[K, V]) redimensionado(capacidadVieja, capacidadNueva int) {
+	if eventos.alRedimensionar != nil && capacidadVieja != capacidadNueva {
+		eventos.alRedimensionar(capacidadVieja, capacidadNueva)
+	}
+}
+
+// anotarRedimension es el AlRedimensionar de los Diccionarios de hash que usan los envoltorios, como el diccionario
+// por inserción o el MultiDiccionario: en lugar de llamar al evento enseguida, cuando el envoltorio todavía no
+// terminó su operación, lo anota para que el envoltorio lo llame con redimensionPendiente. Es nil si no hay un
+// AlRedimensionar registrado, así el Diccionario interno no tiene eventos
+func (eventos *eventosHash[K, V]) anotarRedimension() func(capacidadVieja, capacidadNueva int) {
+	if eventos.alRedimensionar == nil {
+		return nil
+	}
+	return func(capacidadVieja, capacidadNueva int) {
+		if !eventos.redimension.pendiente {
+			eventos.redimension.vieja = capacidadVieja
+			eventos.redimension.pendiente = true
+		}
+		eventos.redimension.nueva = capacidadNueva
+	}
+}
+
+func (eventos *eventosHash[K, V]) redimensionPendiente() {
+	if !eventos.redimension.pendiente {
+		return
+	}
+	eventos.redimension.pendiente = false
+	eventos.redimensionado(eventos.redimension.vieja, eventos.redimension.nueva)
+}
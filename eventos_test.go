@@ -0,0 +1,272 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type guardadoRegistrado struct {
+	clave        string
+	viejo, nuevo int
+	existia      bool
+}
+
+func TestEventosGuardarYBorrar(t *testing.T) {
+	t.Log("AlGuardar recibe el dato anterior y si la clave existía, AlBorrar el dato borrado, y los borrados de " +
+		"claves que no pertenecen no generan eventos")
+	for nombre, variante := range VARIANTES_BINARIO {
+		t.Run(nombre, func(t *testing.T) {
+			var guardados []guardadoRegistrado
+			var borrados []string
+			var dic TDADiccionario.Diccionario[string, int]
+			dic = TDADiccionario.CrearHashCon(TDADiccionario.Opciones[string, int]{
+				Variante: variante,
+				AlGuardar: func(clave string, viejo, nuevo int, existia bool) {
+					require.EqualValues(t, nuevo, dic.Obtener(clave))
+					guardados = append(guardados, guardadoRegistrado{clave, viejo, nuevo, existia})
+				},
+				AlBorrar: func(clave string, dato int) {
+					require.False(t, dic.Pertenece(clave))
+					borrados = append(borrados, fmt.Sprint(clave, "=", dato))
+				},
+			})
+			dic.Guardar("a", 1)
+			dic.Guardar("b", 2)
+			dic.Guardar("a", 10)
+			require.EqualValues(t, 2, dic.Borrar("b"))
+			_, ok := dic.BorrarOk("b")
+			require.False(t, ok)
+
+			require.EqualValues(t, []guardadoRegistrado{
+				{"a", 0, 1, false},
+				{"b", 0, 2, false},
+				{"a", 1, 10, true},
+			}, guardados)
+			require.EqualValues(t, []string{"b=2"}, borrados)
+		})
+	}
+}
+
+func TestEventosGuardarConStash(t *testing.T) {
+	t.Log("AlGuardar informa el dato anterior aunque la clave esté en el stash, o se haya rehasheado la tabla")
+	for _, capacidadStash := range []int{TDADiccionario.CAPACIDAD_STASH, -1} {
+		guardados := 0
+		reemplazados := 0
+		dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{
+			MaxDesplazamientos: 1,
+			CapacidadStash:     capacidadStash,
+			AlGuardar: func(clave, viejo, nuevo int, existia bool) {
+				guardados++
+				if existia {
+					require.EqualValues(t, -clave, viejo)
+					reemplazados++
+				}
+			},
+		})
+		for i := 0; i < 1000; i++ {
+			dic.Guardar(i, -i)
+		}
+		for i := 0; i < 1000; i++ {
+			dic.Guardar(i, i)
+		}
+		require.EqualValues(t, 2000, guardados)
+		require.EqualValues(t, 1000, reemplazados)
+	}
+}
+
+func TestEventosRedimensionar(t *testing.T) {
+	t.Log("AlRedimensionar recibe la capacidad anterior y la nueva en celdas, cada vez que la tabla cambia de tamaño")
+	for nombre, variante := range VARIANTES_BINARIO {
+		t.Run(nombre, func(t *testing.T) {
+			var dic TDADiccionario.Diccionario[int, int]
+			capacidad := 0
+			agrandamientos, achicamientos := 0, 0
+			dic = TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{
+				Variante: variante,
+				AlRedimensionar: func(vieja, nueva int) {
+					require.EqualValues(t, capacidad, vieja)
+					require.NotEqual(t, vieja, nueva)
+					if nueva > vieja {
+						agrandamientos++
+					} else {
+						achicamientos++
+					}
+					capacidad = nueva
+				},
+			})
+			capacidad = estadisticasDe(dic).Capacidad
+			for i := 0; i < 10000; i++ {
+				dic.Guardar(i, i)
+			}
+			for i := 0; i < 9990; i++ {
+				dic.Borrar(i)
+			}
+			estadisticas := estadisticasDe(dic)
+			require.EqualValues(t, estadisticas.Capacidad, capacidad)
+			require.EqualValues(t, estadisticas.Agrandamientos, agrandamientos)
+			require.EqualValues(t, estadisticas.Achicamientos, achicamientos)
+			require.Greater(t, achicamientos, 0)
+		})
+	}
+}
+
+func TestEventosReentrantes(t *testing.T) {
+	t.Log("Los eventos se llaman con la operación terminada: AlRedimensionar ya ve la clave nueva, y AlGuardar y " +
+		"AlBorrar pueden volver a modificar el diccionario, incluso provocando otra redimensión")
+	for nombre, variante := range VARIANTES_BINARIO {
+		t.Run(nombre, func(t *testing.T) {
+			var dic TDADiccionario.Diccionario[int, int]
+			ultima, insertando := 0, true
+			redimensiones := 0
+			dic = TDADiccionario.CrearHashCon(TDADiccionario.Opciones[int, int]{
+				Variante: variante,
+				AlGuardar: func(clave, _, _ int, existia bool) {
+					if clave >= 0 && !existia {
+						dic.Guardar(-clave-1, clave)
+					}
+				},
+				AlBorrar: func(clave, _ int) {
+					if clave >= 0 {
+						dic.Borrar(-clave - 1)
+					}
+				},
+				AlRedimensionar: func(int, int) {
+					require.True(t, !insertando || dic.Pertenece(ultima))
+					redimensiones++
+				},
+			})
+			for ultima = 0; ultima < 5000; ultima++ {
+				dic.Guardar(ultima, ultima)
+			}
+			require.EqualValues(t, 10000, dic.Cantidad())
+			for i := 0; i < 5000; i++ {
+				require.EqualValues(t, i, dic.Obtener(-i-1))
+			}
+			require.Greater(t, redimensiones, 0)
+			insertando = false
+			for i := 0; i < 5000; i++ {
+				dic.Borrar(i)
+			}
+			require.EqualValues(t, 0, dic.Cantidad())
+		})
+	}
+}
+
+func TestEventosConcurrente(t *testing.T) {
+	t.Log("El diccionario concurrente pasa los eventos a cada uno de sus fragmentos")
+	guardados, borrados := 0, 0
+	dic := TDADiccionario.CrearHashConcurrenteCon(4, TDADiccionario.Opciones[int, int]{
+		AlGuardar: func(int, int, int, bool) { guardados++ },
+		AlBorrar:  func(int, int) { borrados++ },
+	})
+	for i := 0; i < 100; i++ {
+		dic.Guardar(i, i)
+	}
+	dic.Actualizar(1, func(dato int, _ bool) int { return dato + 1 })
+	dic.Borrar(2)
+	require.EqualValues(t, 101, guardados)
+	require.EqualValues(t, 1, borrados)
+}
+
+func TestEventosPorInsercion(t *testing.T) {
+	t.Log("El diccionario por inserción llama a los eventos de sus opciones con la clave ya enlazada en la lista, y " +
+		"AlRedimensionar informa la capacidad del índice")
+	var dic TDADiccionario.DiccionarioPorInsercion[int, int]
+	guardados, reemplazados, borrados, redimensiones := 0, 0, 0, 0
+	dic = TDADiccionario.CrearHashPorInsercionCon(TDADiccionario.ORDEN_INSERCION, TDADiccionario.Opciones[int, int]{
+		AlGuardar: func(clave, viejo, nuevo int, existia bool) {
+			ultima, _ := dic.Ultimo()
+			if existia {
+				require.EqualValues(t, clave, viejo)
+				reemplazados++
+			} else {
+				require.EqualValues(t, clave, ultima)
+			}
+			guardados++
+		},
+		AlBorrar: func(clave, dato int) {
+			require.False(t, dic.Pertenece(clave))
+			borrados++
+		},
+		AlRedimensionar: func(vieja, nueva int) {
+			ultima, _ := dic.Ultimo()
+			require.True(t, dic.Pertenece(ultima))
+			redimensiones++
+		},
+	})
+	for i := 0; i < 1000; i++ {
+		dic.Guardar(i, i)
+	}
+	dic.Guardar(0, 10)
+	for i := 1; i < 1000; i++ {
+		dic.Borrar(i)
+	}
+	require.EqualValues(t, 1001, guardados)
+	require.EqualValues(t, 1, reemplazados)
+	require.EqualValues(t, 999, borrados)
+	require.Greater(t, redimensiones, 1)
+}
+
+func TestEventosMultiDiccionario(t *testing.T) {
+	t.Log("El MultiDiccionario llama a AlGuardar con cada dato agregado y a AlBorrar con cada dato borrado")
+	var guardados []guardadoRegistrado
+	var borrados []string
+	multi := TDADiccionario.CrearMultiDiccionarioCon(TDADiccionario.Opciones[string, int]{
+		AlGuardar: func(clave string, viejo, nuevo int, existia bool) {
+			guardados = append(guardados, guardadoRegistrado{clave, viejo, nuevo, existia})
+		},
+		AlBorrar: func(clave string, dato int) {
+			borrados = append(borrados, fmt.Sprint(clave, "=", dato))
+		},
+	})
+	multi.Guardar("a", 1)
+	multi.Guardar("a", 2)
+	multi.Guardar("b", 3)
+	multi.Guardar("b", 4)
+	require.True(t, multi.BorrarValor("a", 1, func(x, y int) bool { return x == y }))
+	require.False(t, multi.BorrarValor("a", 5, func(x, y int) bool { return x == y }))
+	multi.BorrarClave("b")
+
+	require.EqualValues(t, []guardadoRegistrado{
+		{"a", 0, 1, false},
+		{"a", 0, 2, true},
+		{"b", 0, 3, false},
+		{"b", 0, 4, true},
+	}, guardados)
+	require.EqualValues(t, []string{"a=1", "b=3", "b=4"}, borrados)
+}
+
+func TestEventosContadorCombinado(t *testing.T) {
+	t.Log("Sumar y Restar crean el contador resultado con las opciones del original, eventos incluidos")
+	guardados := 0
+	contador := TDADiccionario.CrearContadorCon(TDADiccionario.Opciones[string, int]{
+		AlGuardar: func(string, int, int, bool) { guardados++ },
+	})
+	contador.Incrementar("a", 2)
+	otro := TDADiccionario.CrearContador[string]()
+	otro.Incrementar("b", 1)
+	guardados = 0
+
+	suma := contador.Sumar(otro)
+	require.EqualValues(t, 2, guardados)
+	suma.Incrementar("c", 1)
+	require.EqualValues(t, 3, guardados)
+}
+
+func BenchmarkGuardarConEventos(b *testing.B) {
+	b.Log("Compara el costo de Guardar sin eventos registrados y con un AlGuardar vacío")
+	for _, conEventos := range []bool{false, true} {
+		b.Run(fmt.Sprintf("ConEventos=%v", conEventos), func(b *testing.B) {
+			opciones := TDADiccionario.Opciones[int, int]{}
+			if conEventos {
+				opciones.AlGuardar = func(int, int, int, bool) {}
+			}
+			dic := TDADiccionario.CrearHashCon(opciones)
+			for i := 0; i < b.N; i++ {
+				dic.Guardar(i%4096, i)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package diccionario
+
+import (
+	"container/heap"
+	"iter"
+	"sync"
+	"time"
+)
+
+// NO_VENCE es el índice en el heap de las entradas sin vencimiento
+const NO_VENCE = -1
+
+// RelojDelSistema es el Reloj que usan por defecto los diccionarios con expiración: devuelve time.Now()
+type RelojDelSistema struct{}
+
+func (RelojDelSistema) Ahora() time.Time {
+	return time.Now()
+}
+
+// OpcionesExpiracion configura un DiccionarioConExpiracion. El valor cero es válido: claves sin vencimiento por
+// defecto, RelojDelSistema y sin limpieza periódica
+type OpcionesExpiracion[K comparable, V any] struct {
+
+	// Reloj da la hora con la que se decide qué claves vencieron. Por defecto es RelojDelSistema
+	Reloj Reloj
+
+	// TTLPorDefecto es el tiempo de vida de las claves guardadas con Guardar. Por defecto no vencen
+	TTLPorDefecto time.Duration
+
+	// IntervaloLimpieza, si es positivo, hace que una goroutine llame a Limpiar con esa frecuencia, hasta que se
+	// llame a Detener
+	IntervaloLimpieza time.Duration
+
+	// Hasher calcula las funciones de hash de las claves, como en Opciones
+	Hasher Hasher[K]
+}
+
+/* Las claves vencidas se borran al intentar usarlas, y además todas juntas cada vez que se consulta la cantidad, se
+recorre el diccionario, o se llama a Limpiar. Para encontrarlas sin recorrer todo el diccionario, las entradas que
+vencen están también en un heap de mínimos ordenado por vencimiento, y cada entrada sabe su posición en el heap para
+poder sacarla o moverla cuando se borra o se vuelve a guardar su clave.
+*/
+
+type dictConExpiracion[K comparable, V any] struct {
+	sync.Mutex
+	entradas      Diccionario[K, *entradaExpiracion[K, V]]
+	vencimientos  heapVencimientos[K, V]
+	reloj         Reloj
+	ttlPorDefecto time.Duration
+	detener       chan struct{}
+	detenerUnaVez sync.Once
+}
+
+type entradaExpiracion[K comparable, V any] struct {
+	clave  K
+	dato   V
+	vence  time.Time
+	indice int
+}
+
+type heapVencimientos[K comparable, V any] []*entradaExpiracion[K, V]
+
+func (h heapVencimientos[K, V]) Len() int           { return len(h) }
+func (h heapVencimientos[K, V]) Less(i, j int) bool { return h[i].vence.Before(h[j].vence) }
+
+func (h heapVencimientos[K, V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].indice, h[j].indice = i, j
+}
+
+func (h *heapVencimientos[K, V]) Push(x any) {
+	entrada := x.(*entradaExpiracion[K, V])
+	entrada.indice = len(*h)
+	*h = append(*h, entrada)
+}
+
+func (h *heapVencimientos[K, V]) Pop() any {
+	ultimo := len(*h) - 1
+	entrada := (*h)[ultimo]
+	(*h)[ultimo] = nil
+	*h = (*h)[:ultimo]
+	entrada.indice = NO_VENCE
+	return entrada
+}
+
+func CrearHashConExpiracion[K comparable, V any]() DiccionarioConExpiracion[K, V] {
+	return CrearHashConExpiracionCon(OpcionesExpiracion[K, V]{})
+}
+
+func CrearHashConExpiracionCon[K comparable, V any](opciones OpcionesExpiracion[K, V]) DiccionarioConExpiracion[K, V] {
+	dict := new(dictConExpiracion[K, V])
+	dict.entradas = CrearHashCon(Opciones[K, *entradaExpiracion[K, V]]{Hasher: opciones.Hasher})
+	dict.reloj = opciones.Reloj
+	if dict.reloj == nil {
+		dict.reloj = RelojDelSistema{}
+	}
+	dict.ttlPorDefecto = opciones.TTLPorDefecto
+	dict.detener = make(chan struct{})
+	if opciones.IntervaloLimpieza > 0 {
+		go dict.limpiarPeriodicamente(opciones.IntervaloLimpieza)
+	}
+	return dict
+}
+
+func (dict *dictConExpiracion[K, V]) limpiarPeriodicamente(intervalo time.Duration) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			dict.Limpiar()
+		case <-dict.detener:
+			return
+		}
+	}
+}
+
+// ###################################### VENCIMIENTOS ######################################################
+
+func (entrada *entradaExpiracion[K, V]) vencida(ahora time.Time) bool {
+	return entrada.indice != NO_VENCE && !ahora.Before(entrada.vence)
+}
+
+// sacar borra la entrada del diccionario y, si vence, del heap. Se llama con el candado tomado
+func (dict *dictConExpiracion[K, V]) sacar(entrada *entradaExpiracion[K, V]) {
+	dict.entradas.Borrar(entrada.clave)
+	if entrada.indice != NO_VENCE {
+		heap.Remove(&dict.vencimientos, entrada.indice)
+	}
+}
+
+// vigente devuelve la entrada de la clave si pertenece y no venció. Si venció, la borra. Se llama con el candado
+// tomado
+func (dict *dictConExpiracion[K, V]) vigente(clave K) (*entradaExpiracion[K, V], bool) {
+	entrada, ok := dict.entradas.ObtenerOk(clave)
+	if !ok {
+		return nil, false
+	}
+	if entrada.vencida(dict.reloj.Ahora()) {
+		dict.sacar(entrada)
+		return nil, false
+	}
+	return entrada, true
+}
+
+// limpiar borra todas las entradas vencidas. Se llama con el candado tomado
+func (dict *dictConExpiracion[K, V]) limpiar() {
+	ahora := dict.reloj.Ahora()
+	for len(dict.vencimientos) > 0 && dict.vencimientos[0].vencida(ahora) {
+		dict.sacar(dict.vencimientos[0])
+	}
+}
+
+func (dict *dictConExpiracion[K, V]) Limpiar() {
+	dict.Lock()
+	defer dict.Unlock()
+	dict.limpiar()
+}
+
+func (dict *dictConExpiracion[K, V]) Detener() {
+	dict.detenerUnaVez.Do(func() { close(dict.detener) })
+}
+
+// ################################### PRIMITIVAS DICCIONARIO #################################################
+
+func (dict *dictConExpiracion[K, V]) Guardar(clave K, dato V) {
+	dict.GuardarConTTL(clave, dato, dict.ttlPorDefecto)
+}
+
+func (dict *dictConExpiracion[K, V]) GuardarConTTL(clave K, dato V, ttl time.Duration) {
+	dict.Lock()
+	defer dict.Unlock()
+	entrada, ok := dict.entradas.ObtenerOk(clave)
+	if !ok {
+		entrada = &entradaExpiracion[K, V]{clave: clave, indice: NO_VENCE}
+		dict.entradas.Guardar(clave, entrada)
+	}
+	entrada.dato = dato
+	switch {
+	case ttl <= 0 && entrada.indice != NO_VENCE:
+		heap.Remove(&dict.vencimientos, entrada.indice)
+	case ttl > 0:
+		entrada.vence = dict.reloj.Ahora().Add(ttl)
+		if entrada.indice == NO_VENCE {
+			heap.Push(&dict.vencimientos, entrada)
+		} else {
+			heap.Fix(&dict.vencimientos, entrada.indice)
+		}
+	}
+}
+
+func (dict *dictConExpiracion[K, V]) Pertenece(clave K) bool {
+	_, ok := dict.ObtenerOk(clave)
+	return ok
+}
+
+func (dict *dictConExpiracion[K, V]) Obtener(clave K) V {
+	dato, ok := dict.ObtenerOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (dict *dictConExpiracion[K, V]) ObtenerOk(clave K) (V, bool) {
+	dict.Lock()
+	defer dict.Unlock()
+	entrada, ok := dict.vigente(clave)
+	if !ok {
+		var cero V
+		return cero, false
+	}
+	return entrada.dato, true
+}
+
+func (dict *dictConExpiracion[K, V]) Borrar(clave K) V {
+	dato, ok := dict.BorrarOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (dict *dictConExpiracion[K, V]) BorrarOk(clave K) (V, bool) {
+	dict.Lock()
+	defer dict.Unlock()
+	entrada, ok := dict.vigente(clave)
+	if !ok {
+		var cero V
+		return cero, false
+	}
+	dict.sacar(entrada)
+	return entrada.dato, true
+}
+
+func (dict *dictConExpiracion[K, V]) Cantidad() int {
+	dict.Lock()
+	defer dict.Unlock()
+	dict.limpiar()
+	return dict.entradas.Cantidad()
+}
+
+// copiar devuelve los pares vigentes del diccionario
+func (dict *dictConExpiracion[K, V]) copiar() []parClaveValor[K, V] {
+	dict.Lock()
+	defer dict.Unlock()
+	dict.limpiar()
+	pares := make([]parClaveValor[K, V], 0, dict.entradas.Cantidad())
+	dict.entradas.Iterar(func(clave K, entrada *entradaExpiracion[K, V]) bool {
+		pares = append(pares, parClaveValor[K, V]{clave, entrada.dato})
+		return true
+	})
+	return pares
+}
+
+// Iterar recorre una copia de las claves vigentes al empezar, así la función puede usar el diccionario sin
+// bloquearse
+func (dict *dictConExpiracion[K, V]) Iterar(visitar func(K, V) bool) {
+	for _, par := range dict.copiar() {
+		if !visitar(par.clave, par.dato) {
+			return
+		}
+	}
+}
+
+func (dict *dictConExpiracion[K, V]) All() iter.Seq2[K, V] {
+	return dict.Iterar
+}
+
+func (dict *dictConExpiracion[K, V]) Claves() iter.Seq[K] {
+	return func(visitar func(K) bool) {
+		dict.Iterar(func(clave K, _ V) bool { return visitar(clave) })
+	}
+}
+
+func (dict *dictConExpiracion[K, V]) Valores() iter.Seq[V] {
+	return func(visitar func(V) bool) {
+		dict.Iterar(func(_ K, dato V) bool { return visitar(dato) })
+	}
+}
+
+// ################################### PRIMITIVAS ITERADOR ###################################################
+
+// Iterador, como Iterar, recorre una copia de las claves vigentes al crearlo
+func (dict *dictConExpiracion[K, V]) Iterador() IterDiccionario[K, V] {
+	return &iteradorCopia[K, V]{pares: dict.copiar()}
+}
+
+type iteradorCopia[K comparable, V any] struct {
+	pares    []parClaveValor[K, V]
+	posicion int
+}
+
+func (iter *iteradorCopia[K, V]) HaySiguiente() bool {
+	return iter.posicion < len(iter.pares)
+}
+
+func (iter *iteradorCopia[K, V]) VerActual() (K, V) {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	actual := iter.pares[iter.posicion]
+	return actual.clave, actual.dato
+}
+
+func (iter *iteradorCopia[K, V]) Siguiente() K {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	clave := iter.pares[iter.posicion].clave
+	iter.posicion++
+	return clave
+}
@@ -0,0 +1,138 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// relojFalso solo avanza cuando la prueba lo pide, y cuenta cuántas veces se le preguntó la hora
+type relojFalso struct {
+	sync.Mutex
+	ahora     time.Time
+	consultas int
+}
+
+func (reloj *relojFalso) Ahora() time.Time {
+	reloj.Lock()
+	defer reloj.Unlock()
+	reloj.consultas++
+	return reloj.ahora
+}
+
+func (reloj *relojFalso) avanzar(tiempo time.Duration) {
+	reloj.Lock()
+	defer reloj.Unlock()
+	reloj.ahora = reloj.ahora.Add(tiempo)
+}
+
+func (reloj *relojFalso) cantidadConsultas() int {
+	reloj.Lock()
+	defer reloj.Unlock()
+	return reloj.consultas
+}
+
+func crearConReloj(ttlPorDefecto time.Duration) (TDADiccionario.DiccionarioConExpiracion[string, int], *relojFalso) {
+	reloj := &relojFalso{ahora: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	dic := TDADiccionario.CrearHashConExpiracionCon(TDADiccionario.OpcionesExpiracion[string, int]{
+		Reloj:         reloj,
+		TTLPorDefecto: ttlPorDefecto,
+	})
+	return dic, reloj
+}
+
+func TestExpiracionClavesVencidasNoPertenecen(t *testing.T) {
+	t.Log("Una clave vencida no pertenece, no se puede obtener ni borrar, y no cuenta en la cantidad ni en los " +
+		"recorridos")
+	dic, reloj := crearConReloj(0)
+	dic.GuardarConTTL("sesion1", 1, time.Minute)
+	dic.GuardarConTTL("sesion2", 2, time.Hour)
+	dic.Guardar("permanente", 3)
+	reloj.avanzar(59 * time.Second)
+	require.True(t, dic.Pertenece("sesion1"))
+	require.EqualValues(t, 3, dic.Cantidad())
+
+	reloj.avanzar(time.Second)
+	require.False(t, dic.Pertenece("sesion1"))
+	_, ok := dic.ObtenerOk("sesion1")
+	require.False(t, ok)
+	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Obtener("sesion1") })
+	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { dic.Borrar("sesion1") })
+	require.EqualValues(t, 2, dic.Cantidad())
+	require.ElementsMatch(t, []string{"sesion2", "permanente"}, slices.Collect(dic.Claves()))
+
+	reloj.avanzar(24 * time.Hour)
+	require.EqualValues(t, 1, dic.Cantidad())
+	iter := dic.Iterador()
+	require.EqualValues(t, "permanente", iter.Siguiente())
+	require.False(t, iter.HaySiguiente())
+}
+
+func TestExpiracionVolverAGuardar(t *testing.T) {
+	t.Log("Volver a guardar una clave reemplaza su vencimiento: con un TTL nuevo vence más tarde, y con un TTL no " +
+		"positivo deja de vencer")
+	dic, reloj := crearConReloj(0)
+	dic.GuardarConTTL("A", 1, time.Minute)
+	dic.GuardarConTTL("B", 1, time.Minute)
+	reloj.avanzar(30 * time.Second)
+	dic.GuardarConTTL("A", 2, time.Minute)
+	dic.GuardarConTTL("B", 2, 0)
+	reloj.avanzar(45 * time.Second)
+	require.EqualValues(t, 2, dic.Obtener("A"))
+	require.EqualValues(t, 2, dic.Obtener("B"))
+	reloj.avanzar(time.Hour)
+	require.False(t, dic.Pertenece("A"))
+	require.EqualValues(t, 2, dic.Obtener("B"))
+	dic.GuardarConTTL("A", 3, time.Minute)
+	require.EqualValues(t, 3, dic.Obtener("A"))
+}
+
+func TestExpiracionTTLPorDefecto(t *testing.T) {
+	t.Log("Guardar usa el TTL por defecto del diccionario")
+	dic, reloj := crearConReloj(time.Minute)
+	dic.Guardar("A", 1)
+	dic.GuardarConTTL("B", 2, time.Hour)
+	reloj.avanzar(2 * time.Minute)
+	require.False(t, dic.Pertenece("A"))
+	require.True(t, dic.Pertenece("B"))
+}
+
+func TestExpiracionLimpiarYBorrar(t *testing.T) {
+	t.Log("Limpiar, y borrar claves con vencimiento, mantienen consistentes los vencimientos del resto")
+	dic, reloj := crearConReloj(0)
+	for i := 0; i < 100; i++ {
+		dic.GuardarConTTL(string(rune('A'+i)), i, time.Duration(i+1)*time.Second)
+	}
+	for i := 0; i < 100; i += 3 {
+		require.EqualValues(t, i, dic.Borrar(string(rune('A'+i))))
+	}
+	reloj.avanzar(50 * time.Second)
+	dic.Limpiar()
+	for i := 0; i < 100; i++ {
+		require.Equal(t, i >= 50 && i%3 != 0, dic.Pertenece(string(rune('A'+i))))
+	}
+}
+
+func TestExpiracionLimpiezaPeriodica(t *testing.T) {
+	t.Log("Con un intervalo de limpieza, una goroutine consulta el reloj y limpia periódicamente hasta que se la " +
+		"detiene, y se puede seguir usando el diccionario mientras tanto")
+	reloj := &relojFalso{}
+	dic := TDADiccionario.CrearHashConExpiracionCon(TDADiccionario.OpcionesExpiracion[int, int]{
+		Reloj:             reloj,
+		IntervaloLimpieza: time.Millisecond,
+	})
+	for i := 0; i < 100; i++ {
+		dic.GuardarConTTL(i, i, time.Second)
+	}
+	consultas := reloj.cantidadConsultas()
+	require.Eventually(t, func() bool { return reloj.cantidadConsultas() > consultas+3 }, time.Second, time.Millisecond)
+	reloj.avanzar(time.Second)
+	require.EqualValues(t, 0, dic.Cantidad())
+	dic.Detener()
+	dic.Detener()
+	dic.Guardar(1, 1)
+	require.EqualValues(t, 1, dic.Obtener(1))
+}
@@ -1,29 +1,77 @@
 package diccionario
 
 import (
-	"fmt"
+	"hash/maphash"
+	"iter"
+	"slices"
 )
 
 const (
-	CAPACIDAD_INICIAL  = 127
-	CAPACIDAD_MAXIMA   = 34521589
-	ANTERIOR_PRIMO     = -1
-	PROX_PRIMO         = 1
-	MAX_FC             = 0.91
-	MIN_FC             = 0.1
-	FACTOR_REDIMENSION = 2
-	TABLA_VACIA        = 0
-	NO_EN_TABLA        = 0
+	CAPACIDAD_INICIAL   = 127
+	CAPACIDAD_MAXIMA    = 34521589
+	ANTERIOR_PRIMO      = -1
+	PROX_PRIMO          = 1
+	MAX_FC              = 0.91
+	MAX_FC_BALDES       = 0.97
+	MIN_FC              = 0.1
+	FACTOR_REDIMENSION  = 2
+	TABLA_VACIA         = 0
+	NO_EN_TABLA         = 0
+	NO_EN_STASH         = -1
+	MAX_REHASHEOS       = 3
+	MAX_AGRANDAMIENTOS  = 4
+	FC_REHASH           = 0.5
+	MAX_DESPLAZAMIENTOS = 64
+	CAPACIDAD_STASH     = 4
+	CELDAS_POR_BALDE    = 4
 
 	PRIMER_HASH  = 1
 	SEGUNDO_HASH = 2
 	ULTIMO_HASH  = 3
 )
 
+// Variante elige cómo se organiza la tabla de un diccionario creado con CrearHashCon
+type Variante int
+
+const (
+	// CUCKOO guarda un único elemento en cada posición de la tabla
+	CUCKOO Variante = iota
+
+	// CUCKOO_CON_BALDES guarda hasta CELDAS_POR_BALDE elementos en cada posición de la tabla. Cada clave tiene así
+	// más lugares posibles, lo que permite trabajar con factores de carga más altos y redimensionar menos seguido
+	CUCKOO_CON_BALDES
+
+	// SWISS_TABLE no es un cuckoo: es una tabla de direccionamiento abierto por grupos al estilo SwissTable, con
+	// claves y valores dentro de la tabla. MaxDesplazamientos y CapacidadStash no se usan
+	SWISS_TABLE
+)
+
 type dictImplementacion[K comparable, V any] struct {
+	// tabla tiene celdas elementos por cada posición (balde) de la tabla, uno a continuación del otro. En la
+	// variante sin baldes, celdas es 1
 	tabla     []*elementoTabla[K, V]
+	celdas    int
+	maxFC     float32
 	elementos int
 	primo     int
+	hasher    Hasher[K]
+	semillas  [ULTIMO_HASH]maphash.Seed
+
+	// stash guarda los elementos que no se pudieron ubicar en la tabla sin superar maxDesplazamientos
+	stash              []*elementoTabla[K, V]
+	capacidadStash     int
+	maxDesplazamientos int
+
+	// modificaciones cuenta los cambios en la estructura de la tabla (claves agregadas o borradas, desplazamientos,
+	// redimensiones y rehasheos), para que los iteradores detecten si el diccionario cambió mientras lo recorrían
+	modificaciones int
+
+	// contadores acumula lo que informa Estadisticas desde que se creó el diccionario o se reiniciaron
+	contadores contadoresHash
+
+	eventos      eventosHash[K, V]
+	codecs       codecsDiccionario[K, V]
+	jsonOrdenado bool
 }
 
 type elementoTabla[K comparable, V any] struct {
@@ -33,312 +81,552 @@ type elementoTabla[K comparable, V any] struct {
 }
 
 type iteradorDict[K comparable, V any] struct {
-	diccionario *dictImplementacion[K, V]
-	posicion    int
+	diccionario    *dictImplementacion[K, V]
+	posicion       int
+	modificaciones int
 }
 
 func crearTabla[K comparable, V any](capacidad int) []*elementoTabla[K, V] {
 	return make([]*elementoTabla[K, V], capacidad)
 }
 
-func CrearHash[K comparable, V any]() Diccionario[K, V] {
-	dict := new(dictImplementacion[K, V])
-	dict.tabla = crearTabla[K, V](CAPACIDAD_INICIAL)
-	return dict
-}
+// Opciones configura un diccionario creado con CrearHashCon. Los campos sin asignar toman su valor por defecto
+type Opciones[K comparable, V any] struct {
 
-// // ###################################### HASHEAR CLAVE ####################################################
+	// Hasher calcula las funciones de hash de las claves. Por defecto se elige uno según el tipo de la clave, y si
+	// no hay ninguno específico se usa HasherGenerico
+	Hasher Hasher[K]
 
-func convertirABytes[K comparable](clave K) []byte {
-	return []byte(fmt.Sprintf("%v", clave))
-}
+	// Variante elige la organización de la tabla. Por defecto es CUCKOO
+	Variante Variante
 
-func posicionEnTabla(opcion int, claveEnBytes []byte, largo int) int {
-	switch opcion {
-	case PRIMER_HASH:
-		return funcionHash1(claveEnBytes, largo)
-	case SEGUNDO_HASH:
-		return funcionHash2(claveEnBytes, largo)
-	case ULTIMO_HASH:
-		return funcionHash3(claveEnBytes, largo)
-	default:
-		panic("HASH INVALIDO")
-	}
-}
+	// MaxDesplazamientos es la cantidad máxima de elementos que se desplazan al guardar una clave antes de recurrir al
+	// stash. Por defecto es MAX_DESPLAZAMIENTOS
+	MaxDesplazamientos int
 
-/*######## FUNCION 1 - DJB2
-Hash: DJB2
-Escrita por Daniel J. Bernstein
-Implementación de https://golangprojectstructure.com/
-*/
+	// CapacidadStash es la cantidad de elementos que pueden quedar fuera de la tabla, en el stash, antes de tener que
+	// rehashear. Por defecto es CAPACIDAD_STASH, y un valor negativo deshabilita el stash
+	CapacidadStash int
 
-func funcionHash1(clave []byte, largo int) int {
-	posicion := djb2(clave) % uint32(largo)
-	return int(posicion)
-}
+	// CodecClaves y CodecDatos convierten claves y datos a bytes y viceversa para MarshalBinary y UnmarshalBinary.
+	// Por defecto se elige uno según el tipo, como con el Hasher, y si no hay ninguno específico se usa CodecGob
+	CodecClaves Codec[K]
+	CodecDatos  Codec[V]
 
-func djb2(data []byte) uint32 {
-	hash := uint32(5381)
+	// JSONOrdenado hace que MarshalJSON escriba las claves ordenadas, para que el resultado sea siempre el mismo. Por
+	// defecto se escriben en el orden en que se recorre el diccionario
+	JSONOrdenado bool
 
-	for _, b := range data {
-		hash += uint32(b) + hash + hash<<5
-	}
+	// AlGuardar, si no es nil, se llama al terminar cada Guardar con la clave, el dato que tenía (el valor cero si no
+	// existía), el nuevo, y si la clave ya existía
+	AlGuardar func(clave K, viejo, nuevo V, existia bool)
 
-	return hash
-}
-
-/*######## FUNCION 2 - FNV
-Hash: Fowler–Noll–Vo (FNV)
-https://en.wikipedia.org/wiki/Fowler%E2%80%93Noll%E2%80%93Vo_hash_function
-Implementación de https://golangprojectstructure.com/
-*/
+	// AlBorrar, si no es nil, se llama después de borrar una clave que pertenecía, con su dato
+	AlBorrar func(clave K, dato V)
 
-func funcionHash2(clave []byte, largo int) int {
-	posicion := fvnHash(clave) % uint64(largo)
-	return int(posicion)
+	// AlRedimensionar, si no es nil, se llama al terminar cada Guardar o Borrar que cambió la capacidad de la tabla,
+	// con la capacidad anterior y la nueva contadas en celdas, como en EstadisticasHash
+	AlRedimensionar func(capacidadVieja, capacidadNueva int)
 }
 
-const (
-	uint64Offset uint64 = 0xcbf29ce484222325
-	uint64Prime  uint64 = 0x00000100000001b3
-)
+func CrearHash[K comparable, V any]() Diccionario[K, V] {
+	return CrearHashCon(Opciones[K, V]{})
+}
 
-func fvnHash(data []byte) (hash uint64) {
-	hash = uint64Offset
+// CrearHashCon crea un Diccionario con las opciones indicadas. El diccionario implementa además
+// encoding.BinaryMarshaler y encoding.BinaryUnmarshaler, con los codecs de las opciones, y json.Marshaler y
+// json.Unmarshaler
+func CrearHashCon[K comparable, V any](opciones Opciones[K, V]) Diccionario[K, V] {
+	if opciones.Variante == SWISS_TABLE {
+		return crearDictSwiss(opciones)
+	}
 
-	for _, b := range data {
-		hash ^= uint64(b)
-		hash *= uint64Prime
+	dict := new(dictImplementacion[K, V])
+	dict.celdas, dict.maxFC = 1, MAX_FC
+	if opciones.Variante == CUCKOO_CON_BALDES {
+		dict.celdas, dict.maxFC = CELDAS_POR_BALDE, MAX_FC_BALDES
+	}
+	dict.tabla = crearTabla[K, V](CAPACIDAD_INICIAL * dict.celdas)
+	dict.hasher = opciones.Hasher
+	if dict.hasher == nil {
+		dict.hasher = HasherPorDefecto[K]()
+	}
+	dict.semillas = nuevasSemillas()
+	dict.maxDesplazamientos = opciones.MaxDesplazamientos
+	if dict.maxDesplazamientos <= 0 {
+		dict.maxDesplazamientos = MAX_DESPLAZAMIENTOS
+	}
+	dict.capacidadStash = opciones.CapacidadStash
+	if dict.capacidadStash == 0 {
+		dict.capacidadStash = CAPACIDAD_STASH
+	} else if dict.capacidadStash < 0 {
+		dict.capacidadStash = 0
 	}
+	dict.eventos = crearEventos(opciones)
+	dict.codecs = crearCodecs(opciones)
+	dict.jsonOrdenado = opciones.JSONOrdenado
+	return dict
+}
 
-	return
+// CrearHashDesde crea un Diccionario con todos los pares de la secuencia. Si una clave aparece más de una vez, queda
+// asociada al último dato
+func CrearHashDesde[K comparable, V any](pares iter.Seq2[K, V]) Diccionario[K, V] {
+	return CrearHashConDesde(Opciones[K, V]{}, pares)
 }
 
-// ######## FUNCION 3 - JENKINS
-/* Hash: jenkins one-at-a-time-hash
-https://en.wikipedia.org/wiki/Jenkins_hash_function
+// CrearHashConDesde es como CrearHashDesde, pero crea el Diccionario con las opciones indicadas
+func CrearHashConDesde[K comparable, V any](opciones Opciones[K, V], pares iter.Seq2[K, V]) Diccionario[K, V] {
+	dict := CrearHashCon(opciones)
+	for clave, dato := range pares {
+		dict.Guardar(clave, dato)
+	}
+	return dict
+}
+
+// // ###################################### HASHEAR CLAVE ####################################################
+
+/* Las tres funciones del cuckoo son hash/maphash con una semilla aleatoria distinta para cada una. Las semillas son
+propias de cada diccionario, así quien elige las claves no puede predecir en qué posiciones van a caer, ni armar un
+conjunto de claves que genere ciclos de desplazamientos a propósito.
 */
 
-func funcionHash3(clave []byte, largo int) int {
-	posicion := jenkins(clave) % uint64(largo)
-	return int(posicion)
+func nuevasSemillas() [ULTIMO_HASH]maphash.Seed {
+	var semillas [ULTIMO_HASH]maphash.Seed
+	for i := range semillas {
+		semillas[i] = maphash.MakeSeed()
+	}
+	return semillas
 }
 
-func jenkins(clave []byte) uint64 {
-	var hash uint64
-	for _, b := range clave {
-		hash += uint64(b)
-		hash += (hash << 10)
-		hash ^= (hash >> 6)
-	}
+func (dict *dictImplementacion[K, V]) posicionEnTabla(opcion int, clave K, largo int) int {
+	return int(dict.hasher.Hashear(clave, opcion, dict.semillas[opcion-1]) % uint64(largo))
+}
 
-	hash += (hash << 3)
-	hash ^= (hash >> 11)
-	hash += (hash << 15)
-	return hash
+// baldeEnTabla devuelve la posición de la primera celda del balde en el que la función indicada ubica a la clave
+func (dict *dictImplementacion[K, V]) baldeEnTabla(opcion int, clave K, tabla []*elementoTabla[K, V]) int {
+	return dict.posicionEnTabla(opcion, clave, len(tabla)/dict.celdas) * dict.celdas
 }
 
 //// ######################################### REDIMENSION ###################################################
 
-func (dict *dictImplementacion[K, V]) nuevaCapacidad(pos int, movimiento int) int {
-	arrayPrimos := []int{
-		CAPACIDAD_INICIAL, 257, 523, 1049, 2099, 4201, 8419, 16843,
-		33703, 67409, 134837, 269683, 539389, 1078787, 2157587, 4315183,
-		8630387, 17260781, CAPACIDAD_MAXIMA,
-	}
+var primos = []int{
+	CAPACIDAD_INICIAL, 257, 523, 1049, 2099, 4201, 8419, 16843,
+	33703, 67409, 134837, 269683, 539389, 1078787, 2157587, 4315183,
+	8630387, 17260781, CAPACIDAD_MAXIMA,
+}
 
-	if pos+movimiento > len(arrayPrimos) {
-		return len(dict.tabla) * 2
+// nuevaCapacidad devuelve la cantidad de baldes que sigue a la actual, o que la precede, según el movimiento. Pasado
+// el último primo, la capacidad se duplica o se divide a la mitad
+func (dict *dictImplementacion[K, V]) nuevaCapacidad(actual int, movimiento int) int {
+	if actual > CAPACIDAD_MAXIMA || (actual == CAPACIDAD_MAXIMA && movimiento == PROX_PRIMO) {
+		if movimiento == PROX_PRIMO {
+			return actual * FACTOR_REDIMENSION
+		}
+		return actual / FACTOR_REDIMENSION
 	}
 
 	dict.primo = dict.primo + movimiento
-	return arrayPrimos[pos+movimiento]
+	return primos[dict.primo]
+}
+
+func (dict *dictImplementacion[K, V]) baldes() int {
+	return len(dict.tabla) / dict.celdas
 }
 
-func pocaCarga(elementos int, largoTabla int) bool {
-	return float32(elementos)/float32(largoTabla) < MIN_FC && largoTabla/FACTOR_REDIMENSION > CAPACIDAD_INICIAL
+func (dict *dictImplementacion[K, V]) pocaCarga() bool {
+	return float32(dict.elementos)/float32(len(dict.tabla)) < MIN_FC && dict.baldes()/FACTOR_REDIMENSION > CAPACIDAD_INICIAL
 }
 
-func sobrecarga(elementos int, largoTabla int) bool {
-	return float32(elementos)/float32(largoTabla) >= MAX_FC
+func (dict *dictImplementacion[K, V]) sobrecarga(elementos int) bool {
+	return float32(elementos)/float32(len(dict.tabla)) >= dict.maxFC
 }
 
+// redimensionar entra en pánico con un mensaje 'El hasher no distribuye las claves en la tabla' si reconstruir no
+// puede ubicar los elementos. El diccionario queda como estaba
 func (dict *dictImplementacion[K, V]) redimensionar(nuevaCapacidad int) {
-	var cantidad int
-	nuevaTabla := crearTabla[K, V](nuevaCapacidad)
+	if !dict.reconstruir(nuevaCapacidad, nil) {
+		panic(ErrHasherDegenerado.Error())
+	}
+}
+
+// reconstruir ubica todos los elementos del diccionario, más el pendiente si lo hay, en una tabla y un stash nuevos
+// con la cantidad de baldes indicada. Si algún elemento queda sin lugar, vuelve a intentar con semillas nuevas, y si luego de
+// MAX_REHASHEOS intentos sigue sin poder, agranda la tabla. Si después de agrandarla MAX_AGRANDAMIENTOS veces tampoco
+// puede, el problema es el hasher, que manda demasiadas claves a las mismas posiciones sin importar la semilla: deja
+// la tabla, las semillas y la capacidad como estaban y devuelve false
+func (dict *dictImplementacion[K, V]) reconstruir(capacidad int, pendiente *elementoTabla[K, V]) bool {
+	anterior, semillas := dict.baldes(), dict.semillas
+	for intentos := 1; ; intentos++ {
+		if nuevaTabla, nuevoStash, ok := dict.reubicar(capacidad, pendiente); ok {
+			dict.tabla = nuevaTabla
+			dict.stash = nuevoStash
+			dict.modificaciones++
+			dict.contadores.redimensionado(anterior, capacidad)
+			return true
+		}
+		dict.semillas = nuevasSemillas()
+		dict.contadores.rehasheos++
+		if intentos == MAX_REHASHEOS*MAX_AGRANDAMIENTOS {
+			// Quien pidió la capacidad ya avanzó o retrocedió el primo, que tiene que volver al de la tabla actual.
+			// Pasado el último primo no cambia
+			if i := slices.Index(primos, anterior); i != -1 {
+				dict.primo = i
+			}
+			dict.semillas = semillas
+			return false
+		}
+		if intentos%MAX_REHASHEOS == 0 {
+			capacidad = dict.nuevaCapacidad(capacidad, PROX_PRIMO)
+		}
+	}
+}
 
-	for iter := dict.Iterador(); iter.HaySiguiente(); {
-		clave, valor := iter.VerActual()
-		dict.guardarEnTabla(nuevaTabla, clave, valor)
-		cantidad++
-		iter.Siguiente()
+func (dict *dictImplementacion[K, V]) reubicar(capacidad int, pendiente *elementoTabla[K, V]) ([]*elementoTabla[K, V], []*elementoTabla[K, V], bool) {
+	nuevaTabla := crearTabla[K, V](capacidad * dict.celdas)
+	var nuevoStash []*elementoTabla[K, V]
+
+	ubicar := func(elemento *elementoTabla[K, V]) bool {
+		sinLugar := dict.insertarEnTabla(nuevaTabla, elemento.clave, elemento.valor)
+		if sinLugar == nil {
+			return true
+		}
+		if len(nuevoStash) == dict.capacidadStash {
+			return false
+		}
+		nuevoStash = append(nuevoStash, sinLugar)
+		return true
+	}
+
+	for _, elemento := range dict.tabla {
+		if elemento != nil && !ubicar(elemento) {
+			return nil, nil, false
+		}
 	}
-	dict.elementos = cantidad
-	dict.tabla = nuevaTabla
+	for _, elemento := range dict.stash {
+		if !ubicar(elemento) {
+			return nil, nil, false
+		}
+	}
+	if pendiente != nil && !ubicar(pendiente) {
+		return nil, nil, false
+	}
+	return nuevaTabla, nuevoStash, true
 }
 
 // ###################################### BÚSQUEDA Y GUARDADO #################################################
 
 func (dict *dictImplementacion[K, V]) buscar(tabla []*elementoTabla[K, V], clave K) (int, int) {
-	claveEnByte := convertirABytes(clave)
+	if dict.elementos == TABLA_VACIA {
+		return NO_EN_TABLA, 0
+	}
 
 	for i := PRIMER_HASH; i <= ULTIMO_HASH; i++ {
-		posicion := posicionEnTabla(i, claveEnByte, len(tabla))
-		//Si la tabla esta vacia, ya devuelve la posicion en la que la clave se encontraria según la 1ra función
-		if dict.elementos == TABLA_VACIA {
-			return NO_EN_TABLA, posicion
+		balde := dict.baldeEnTabla(i, clave, tabla)
+		for celda := balde; celda < balde+dict.celdas; celda++ {
+			if tabla[celda] != nil && tabla[celda].clave == clave {
+				return i, celda
+			}
 		}
+	}
+
+	return NO_EN_TABLA, 0
+}
 
-		if tabla[posicion] != nil && tabla[posicion].clave == clave {
-			return i, posicion
+// lugarLibre devuelve la función de hash y la posición de la primera celda vacía entre los baldes de la clave. Si
+// están todos llenos, devuelve la primera celda del balde de la primera función, cuyo elemento hay que desplazar
+func (dict *dictImplementacion[K, V]) lugarLibre(tabla []*elementoTabla[K, V], clave K) (int, int) {
+	for i := PRIMER_HASH; i <= ULTIMO_HASH; i++ {
+		balde := dict.baldeEnTabla(i, clave, tabla)
+		for celda := balde; celda < balde+dict.celdas; celda++ {
+			if tabla[celda] == nil {
+				return i, celda
+			}
 		}
 	}
 
-	return NO_EN_TABLA, posicionEnTabla(PRIMER_HASH, claveEnByte, len(tabla))
+	return PRIMER_HASH, dict.baldeEnTabla(PRIMER_HASH, clave, tabla)
 }
 
-func (dict *dictImplementacion[K, V]) guardarEnOcupado(tabla []*elementoTabla[K, V], elemento *elementoTabla[K, V], claveOriginal K, cnt int) bool {
-	if elemento.clave == claveOriginal {
-		return false
+func (dict *dictImplementacion[K, V]) buscarEnStash(clave K) int {
+	for i, elemento := range dict.stash {
+		if elemento.clave == clave {
+			return i
+		}
 	}
-	cnt++
-	claveEnByte := convertirABytes(elemento.clave)
+	return NO_EN_STASH
+}
 
-	nuevaOpcion := elemento.opcion + 1
-	if nuevaOpcion > ULTIMO_HASH {
-		nuevaOpcion = PRIMER_HASH
-	}
-	indice := posicionEnTabla(nuevaOpcion, claveEnByte, len(tabla))
-	elementoAMover := tabla[indice]
-	tabla[indice] = &elementoTabla[K, V]{clave: elemento.clave, valor: elemento.valor, opcion: nuevaOpcion}
+// guardarEnOcupado reubica el elemento desplazado en el balde de su siguiente función de hash. Si el balde está lleno,
+// desplaza a su vez a uno de los elementos del balde, rotando la celda elegida en cada paso. Si luego de
+// maxDesplazamientos sigue habiendo un elemento desplazado, lo devuelve para que se guarde en el stash
+func (dict *dictImplementacion[K, V]) guardarEnOcupado(tabla []*elementoTabla[K, V], elemento *elementoTabla[K, V]) *elementoTabla[K, V] {
+	cnt := 0
+	defer func() { dict.contadores.desplazados(cnt) }()
+	for ; elemento != nil; cnt++ {
+		if cnt == dict.maxDesplazamientos {
+			return elemento
+		}
 
-	if elementoAMover != nil {
-		return dict.guardarEnOcupado(tabla, elementoAMover, claveOriginal, cnt)
+		nuevaOpcion := elemento.opcion + 1
+		if nuevaOpcion > ULTIMO_HASH {
+			nuevaOpcion = PRIMER_HASH
+		}
+		balde := dict.baldeEnTabla(nuevaOpcion, elemento.clave, tabla)
+		indice := balde + cnt%dict.celdas
+		for celda := balde; celda < balde+dict.celdas; celda++ {
+			if tabla[celda] == nil {
+				indice = celda
+				break
+			}
+		}
+		elementoAMover := tabla[indice]
+		elemento.opcion = nuevaOpcion
+		tabla[indice] = elemento
+		elemento = elementoAMover
 	}
 
-	return true
+	return nil
 }
 
-func (dict *dictImplementacion[K, V]) guardarEnTabla(tabla []*elementoTabla[K, V], claveAEvaluar K, dato V) {
-	hash, indice := dict.buscar(tabla, claveAEvaluar)
-	//CLAVE EXISTE: actualizamos
-	if hash != NO_EN_TABLA {
-		tabla[indice].valor = dato
-		return
-	}
-
-	//CLAVE NO EXISTE:
-	//Guardamos elemento original:
+// insertarEnTabla guarda en la tabla indicada el par de una clave que no está en el diccionario. Si se alcanzó el
+// límite de desplazamientos, devuelve el elemento que quedó sin lugar en la tabla
+func (dict *dictImplementacion[K, V]) insertarEnTabla(tabla []*elementoTabla[K, V], clave K, dato V) *elementoTabla[K, V] {
+	opcion, indice := dict.lugarLibre(tabla, clave)
 	elementoAMover := tabla[indice]
-	tabla[indice] = &elementoTabla[K, V]{clave: claveAEvaluar, valor: dato, opcion: 1}
-	dict.elementos++
+	tabla[indice] = &elementoTabla[K, V]{clave: clave, valor: dato, opcion: opcion}
 
 	//Posición no vacia, comenzamos a mover
 	if elementoAMover != nil {
-		if !dict.guardarEnOcupado(tabla, elementoAMover, claveAEvaluar, 0) {
-			capacidad := dict.nuevaCapacidad(dict.primo, PROX_PRIMO)
-			dict.redimensionar(capacidad)
-			dict.Guardar(claveAEvaluar, dato)
-		}
+		return dict.guardarEnOcupado(tabla, elementoAMover)
 	}
-
+	return nil
 }
 
 // ################################### PRIMITIVAS DICCIONARIO #################################################
 
 func (dict *dictImplementacion[K, V]) Guardar(claveAEvaluar K, dato V) {
-	if sobrecarga(dict.elementos+1, len(dict.tabla)) {
-		capacidad := dict.nuevaCapacidad(dict.primo, PROX_PRIMO)
+	//CLAVE EXISTE: actualizamos, sin tocar la estructura de la tabla
+	if i := dict.buscarEnStash(claveAEvaluar); i != NO_EN_STASH {
+		viejo := dict.stash[i].valor
+		dict.stash[i].valor = dato
+		dict.eventos.guardado(claveAEvaluar, viejo, dato, true)
+		return
+	}
+	if hash, indice := dict.buscar(dict.tabla, claveAEvaluar); hash != NO_EN_TABLA {
+		viejo := dict.tabla[indice].valor
+		dict.tabla[indice].valor = dato
+		dict.eventos.guardado(claveAEvaluar, viejo, dato, true)
+		return
+	}
+
+	//CLAVE NO EXISTE: solo una clave nueva puede llevar la tabla por encima del factor de carga
+	capacidadVieja := len(dict.tabla)
+	if dict.sobrecarga(dict.elementos + 1) {
+		capacidad := dict.nuevaCapacidad(dict.baldes(), PROX_PRIMO)
 		dict.redimensionar(capacidad)
 	}
+	sinLugar := dict.insertarEnTabla(dict.tabla, claveAEvaluar, dato)
+	dict.elementos++
+	dict.modificaciones++
+	if sinLugar != nil && !dict.guardarSinLugar(sinLugar) {
+		dict.descartarNueva(claveAEvaluar, sinLugar)
+		panic(ErrHasherDegenerado.Error())
+	}
+	var cero V
+	dict.eventos.redimensionado(capacidadVieja, len(dict.tabla))
+	dict.eventos.guardado(claveAEvaluar, cero, dato, false)
+}
+
+// guardarSinLugar guarda en el stash el elemento que quedó sin lugar en la tabla, o si el stash está lleno vuelve a
+// armar la tabla con él. Si no puede armarla devuelve false, con la tabla y las semillas como estaban
+func (dict *dictImplementacion[K, V]) guardarSinLugar(sinLugar *elementoTabla[K, V]) bool {
+	if len(dict.stash) < dict.capacidadStash {
+		dict.stash = append(dict.stash, sinLugar)
+		return true
+	}
+
+	//El stash está lleno: con la tabla poco cargada se debe a las semillas y no a la falta de lugar, así que en
+	//lugar de agrandar se vuelve a hashear con semillas nuevas
+	capacidad := dict.baldes()
+	if float32(dict.elementos)/float32(len(dict.tabla)) >= FC_REHASH {
+		capacidad = dict.nuevaCapacidad(capacidad, PROX_PRIMO)
+	}
+	semillas := dict.semillas
+	dict.semillas = nuevasSemillas()
+	dict.contadores.rehasheos++
+	if !dict.reconstruir(capacidad, sinLugar) {
+		dict.semillas = semillas
+		return false
+	}
+	return true
+}
 
-	dict.guardarEnTabla(dict.tabla, claveAEvaluar, dato)
+// descartarNueva deshace el guardado de una clave nueva cuando guardarSinLugar falla. Los desplazamientos de
+// insertarEnTabla dejaron a la clave en la tabla y a otro elemento sin lugar, salvo que sea ella misma: se la saca
+// de la tabla y el elemento sin lugar pasa al stash, aunque quede por encima de su capacidad hasta la próxima
+// reconstrucción
+func (dict *dictImplementacion[K, V]) descartarNueva(clave K, sinLugar *elementoTabla[K, V]) {
+	if sinLugar.clave != clave {
+		_, indice := dict.buscar(dict.tabla, clave)
+		dict.tabla[indice] = nil
+		dict.stash = append(dict.stash, sinLugar)
+	}
+	dict.elementos--
+}
 
+func (dict *dictImplementacion[K, V]) Pertenece(clave K) bool {
+	_, ok := dict.ObtenerOk(clave)
+	return ok
 }
 
-func (dict dictImplementacion[K, V]) Pertenece(clave K) bool {
-	hash, _ := dict.buscar(dict.tabla, clave)
-	return hash != NO_EN_TABLA
+func (dict *dictImplementacion[K, V]) Obtener(clave K) V {
+	dato, ok := dict.ObtenerOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
 }
 
-func (dict dictImplementacion[K, V]) Obtener(clave K) V {
+func (dict *dictImplementacion[K, V]) ObtenerOk(clave K) (V, bool) {
 	hash, indice := dict.buscar(dict.tabla, clave)
-	if hash == NO_EN_TABLA {
-		panic("La clave no pertenece al diccionario")
+	if hash != NO_EN_TABLA {
+		return dict.tabla[indice].valor, true
 	}
-	return dict.tabla[indice].valor
+	if i := dict.buscarEnStash(clave); i != NO_EN_STASH {
+		return dict.stash[i].valor, true
+	}
+	var cero V
+	return cero, false
 }
 
 func (dict *dictImplementacion[K, V]) Borrar(clave K) V {
-	hash, indice := dict.buscar(dict.tabla, clave)
-	if hash == NO_EN_TABLA {
-		panic("La clave no pertenece al diccionario")
+	dato, ok := dict.BorrarOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
 	}
+	return dato
+}
 
-	borrado := dict.tabla[indice]
-	dict.tabla[indice] = nil
+func (dict *dictImplementacion[K, V]) BorrarOk(clave K) (V, bool) {
+	var borrado *elementoTabla[K, V]
+	if hash, indice := dict.buscar(dict.tabla, clave); hash != NO_EN_TABLA {
+		borrado = dict.tabla[indice]
+		dict.tabla[indice] = nil
+	} else if i := dict.buscarEnStash(clave); i != NO_EN_STASH {
+		borrado = dict.stash[i]
+		ultimo := len(dict.stash) - 1
+		dict.stash[i] = dict.stash[ultimo]
+		dict.stash[ultimo] = nil
+		dict.stash = dict.stash[:ultimo]
+	} else {
+		var cero V
+		return cero, false
+	}
 	dict.elementos--
+	dict.modificaciones++
 
-	if pocaCarga(dict.elementos, len(dict.tabla)) {
-		capacidad := dict.nuevaCapacidad(dict.primo, ANTERIOR_PRIMO)
-		dict.redimensionar(capacidad)
+	capacidadVieja := len(dict.tabla)
+	if dict.pocaCarga() {
+		// Si los elementos no entran en la tabla más chica, se queda con la actual: el borrado ya está hecho
+		capacidad := dict.nuevaCapacidad(dict.baldes(), ANTERIOR_PRIMO)
+		dict.reconstruir(capacidad, nil)
 	}
+	capacidadNueva := len(dict.tabla)
+	dict.eventos.borrado(clave, borrado.valor)
+	dict.eventos.redimensionado(capacidadVieja, capacidadNueva)
 
-	return borrado.valor
+	return borrado.valor, true
 }
 
-func (dict dictImplementacion[K, V]) Cantidad() int {
+func (dict *dictImplementacion[K, V]) Cantidad() int {
 	return dict.elementos
 }
 
-func (dict dictImplementacion[K, V]) Iterar(visitar func(K, V) bool) {
-	for i := 0; i < len(dict.tabla); i++ {
-		if dict.tabla[i] != nil {
-			if !visitar(dict.tabla[i].clave, dict.tabla[i].valor) {
+// Iterar entra en pánico si la función visitar agrega o borra claves del diccionario
+func (dict *dictImplementacion[K, V]) Iterar(visitar func(K, V) bool) {
+	modificaciones := dict.modificaciones
+	for i := 0; i < dict.posiciones(); i++ {
+		if elemento := dict.elementoEn(i); elemento != nil {
+			if !visitar(elemento.clave, elemento.valor) {
 				break
 			}
+			if dict.modificaciones != modificaciones {
+				panic(ErrDiccionarioModificado.Error())
+			}
 		}
 	}
 }
 
+func (dict *dictImplementacion[K, V]) All() iter.Seq2[K, V] {
+	return dict.Iterar
+}
+
+func (dict *dictImplementacion[K, V]) Claves() iter.Seq[K] {
+	return func(visitar func(K) bool) {
+		dict.Iterar(func(clave K, _ V) bool { return visitar(clave) })
+	}
+}
+
+func (dict *dictImplementacion[K, V]) Valores() iter.Seq[V] {
+	return func(visitar func(V) bool) {
+		dict.Iterar(func(_ K, dato V) bool { return visitar(dato) })
+	}
+}
+
 // ################################### PRIMITIVAS ITERADOR ###################################################
 
+// Los iteradores recorren primero las posiciones de la tabla y luego las del stash, como si este estuviera a
+// continuación de aquella
+
+func (dict *dictImplementacion[K, V]) posiciones() int {
+	return len(dict.tabla) + len(dict.stash)
+}
+
+func (dict *dictImplementacion[K, V]) elementoEn(posicion int) *elementoTabla[K, V] {
+	if posicion < len(dict.tabla) {
+		return dict.tabla[posicion]
+	}
+	return dict.stash[posicion-len(dict.tabla)]
+}
+
+func (dict *dictImplementacion[K, V]) siguienteOcupada(desde int) int {
+	for desde < dict.posiciones() && dict.elementoEn(desde) == nil {
+		desde++
+	}
+	return desde
+}
+
 func (dict *dictImplementacion[K, V]) Iterador() IterDiccionario[K, V] {
-	for i := range dict.tabla {
-		if dict.tabla[i] != nil {
-			return &iteradorDict[K, V]{diccionario: dict, posicion: i}
-		}
+	return &iteradorDict[K, V]{
+		diccionario:    dict,
+		posicion:       dict.siguienteOcupada(0),
+		modificaciones: dict.modificaciones,
 	}
-	return &iteradorDict[K, V]{diccionario: dict, posicion: len(dict.tabla)}
 }
 
+// HaySiguiente, y con ella el resto de las primitivas, entra en pánico si el diccionario cambió desde que se creó
+// el iterador: la posición guardada puede haber quedado fuera de la tabla, o apuntar a otro elemento
 func (iter *iteradorDict[K, V]) HaySiguiente() bool {
-	return iter.posicion < len(iter.diccionario.tabla)
+	if iter.modificaciones != iter.diccionario.modificaciones {
+		panic(ErrDiccionarioModificado.Error())
+	}
+	return iter.posicion < iter.diccionario.posiciones()
 }
 
 func (iter *iteradorDict[K, V]) VerActual() (K, V) {
 	if !iter.HaySiguiente() {
-		panic("El iterador termino de iterar")
+		panic(ErrIteradorTerminado.Error())
 	}
-	return iter.diccionario.tabla[iter.posicion].clave, iter.diccionario.tabla[iter.posicion].valor
+	elemento := iter.diccionario.elementoEn(iter.posicion)
+	return elemento.clave, elemento.valor
 }
 
 func (iter *iteradorDict[K, V]) Siguiente() K {
 	if !iter.HaySiguiente() {
-		panic("El iterador termino de iterar")
-	}
-
-	posActual := iter.posicion
-
-	iter.posicion++
-	if iter.posicion < len(iter.diccionario.tabla) {
-		for i := iter.posicion; i < len(iter.diccionario.tabla); i++ {
-			if iter.diccionario.tabla[iter.posicion] != nil {
-				break
-			}
-			iter.posicion++
-		}
+		panic(ErrIteradorTerminado.Error())
 	}
 
-	return iter.diccionario.tabla[posActual].clave
+	actual := iter.diccionario.elementoEn(iter.posicion)
+	iter.posicion = iter.diccionario.siguienteOcupada(iter.posicion + 1)
+	return actual.clave
 }
@@ -0,0 +1,118 @@
+package diccionario
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+)
+
+// Entero agrupa a todos los tipos enteros que puede hashear HasherEntero
+type Entero interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// ArregloDeBytes agrupa a los arreglos de bytes de largos habituales (IPv4, UUID, SHA-1, SHA-256, etc.) que puede
+// hashear HasherArregloDeBytes
+type ArregloDeBytes interface {
+	~[2]byte | ~[4]byte | ~[6]byte | ~[8]byte | ~[12]byte | ~[16]byte | ~[20]byte | ~[32]byte | ~[64]byte
+}
+
+// HasherString hashea los bytes de la clave directamente, sin copiarla
+type HasherString[K ~string] struct{}
+
+// HasherEntero hashea los 8 bytes de la representación del entero, sin importar su ancho
+type HasherEntero[K Entero] struct{}
+
+// HasherArregloDeBytes hashea el contenido del arreglo directamente
+type HasherArregloDeBytes[K ArregloDeBytes] struct{}
+
+// HasherGenerico formatea la clave con fmt.Sprintf y hashea el resultado. Sirve para cualquier tipo de clave, pero
+// reserva memoria en cada llamado, por lo que solo se usa cuando no hay un hasher específico para el tipo
+type HasherGenerico[K comparable] struct{}
+
+func (HasherString[K]) Hashear(clave K, _ int, semilla maphash.Seed) uint64 {
+	return hashearString(string(clave), semilla)
+}
+
+func (HasherEntero[K]) Hashear(clave K, _ int, semilla maphash.Seed) uint64 {
+	var hash maphash.Hash
+	var bytes [8]byte
+	binary.LittleEndian.PutUint64(bytes[:], uint64(clave))
+	hash.SetSeed(semilla)
+	hash.Write(bytes[:])
+	return hash.Sum64()
+}
+
+func (HasherArregloDeBytes[K]) Hashear(clave K, _ int, semilla maphash.Seed) uint64 {
+	var hash maphash.Hash
+	hash.SetSeed(semilla)
+	for i := 0; i < len(clave); i++ {
+		hash.WriteByte(clave[i])
+	}
+	return hash.Sum64()
+}
+
+func (HasherGenerico[K]) Hashear(clave K, _ int, semilla maphash.Seed) uint64 {
+	return hashearString(fmt.Sprintf("%v", clave), semilla)
+}
+
+func hashearString(clave string, semilla maphash.Seed) uint64 {
+	var hash maphash.Hash
+	hash.SetSeed(semilla)
+	hash.WriteString(clave)
+	return hash.Sum64()
+}
+
+// HasherPorDefecto elige el hasher sin reserva de memoria que corresponda al tipo de la clave, o el genérico si no
+// hay ninguno. Solo reconoce los tipos predeclarados: para tipos definidos por el usuario hay que indicar el hasher
+// en las Opciones
+func HasherPorDefecto[K comparable]() Hasher[K] {
+	var hasher any
+	switch any(*new(K)).(type) {
+	case string:
+		hasher = HasherString[string]{}
+	case int:
+		hasher = HasherEntero[int]{}
+	case int8:
+		hasher = HasherEntero[int8]{}
+	case int16:
+		hasher = HasherEntero[int16]{}
+	case int32:
+		hasher = HasherEntero[int32]{}
+	case int64:
+		hasher = HasherEntero[int64]{}
+	case uint:
+		hasher = HasherEntero[uint]{}
+	case uint8:
+		hasher = HasherEntero[uint8]{}
+	case uint16:
+		hasher = HasherEntero[uint16]{}
+	case uint32:
+		hasher = HasherEntero[uint32]{}
+	case uint64:
+		hasher = HasherEntero[uint64]{}
+	case uintptr:
+		hasher = HasherEntero[uintptr]{}
+	case [2]byte:
+		hasher = HasherArregloDeBytes[[2]byte]{}
+	case [4]byte:
+		hasher = HasherArregloDeBytes[[4]byte]{}
+	case [6]byte:
+		hasher = HasherArregloDeBytes[[6]byte]{}
+	case [8]byte:
+		hasher = HasherArregloDeBytes[[8]byte]{}
+	case [12]byte:
+		hasher = HasherArregloDeBytes[[12]byte]{}
+	case [16]byte:
+		hasher = HasherArregloDeBytes[[16]byte]{}
+	case [20]byte:
+		hasher = HasherArregloDeBytes[[20]byte]{}
+	case [32]byte:
+		hasher = HasherArregloDeBytes[[32]byte]{}
+	case [64]byte:
+		hasher = HasherArregloDeBytes[[64]byte]{}
+	default:
+		return HasherGenerico[K]{}
+	}
+	return hasher.(Hasher[K])
+}
@@ -0,0 +1,329 @@
+package diccionario
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+/* Los diccionarios se representan en JSON como un objeto, con las mismas reglas que usa encoding/json para los
+mapas: las claves de tipo string se usan directamente, las que implementan encoding.TextMarshaler se convierten con
+MarshalText, y las enteras se escriben en decimal. Los datos se codifican con encoding/json.
+
+Implementan json.Marshaler y json.Unmarshaler todos los diccionarios, incluidos los de los paquetes abierto, cerrado
+y disco. Los que guardan estado además de los pares (el orden de uso de la CacheLRU, los vencimientos, la política de
+datos repetidos) escriben solo los pares y los cargan con Guardar. El MultiDiccionario escribe un arreglo con los
+datos de cada clave, el Contador cada clave con su cuenta, y el Conjunto un arreglo con sus elementos.
+*/
+
+// CodificarJSON escribe el diccionario como un objeto JSON, par por par, sin armar antes un mapa. Si ordenado es
+// true, las claves se escriben ordenadas según su representación en JSON, y si no en el orden en que se recorre el
+// diccionario
+func CodificarJSON[K comparable, V any](escritor io.Writer, dict Diccionario[K, V], ordenado bool) error {
+	salida := bufio.NewWriter(escritor)
+	if err := escribirObjetoJSON(salida, dict.Iterar, ordenado); err != nil {
+		return err
+	}
+	return salida.Flush()
+}
+
+// DecodificarJSON lee un objeto JSON y guarda cada uno de sus pares en el diccionario apenas lo lee, sin armar
+// antes un mapa. Las claves que ya estaban en el diccionario se conservan, como al decodificar un mapa con
+// encoding/json, y un null no guarda nada. Si hay un error, los pares leídos hasta ese momento quedan guardados
+func DecodificarJSON[K comparable, V any](lector io.Reader, dict Diccionario[K, V]) error {
+	decodificador := json.NewDecoder(lector)
+	token, err := decodificador.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('{') {
+		return fmt.Errorf("%w: se esperaba un objeto JSON", ErrFormatoInvalido)
+	}
+	for decodificador.More() {
+		token, err := decodificador.Token()
+		if err != nil {
+			return err
+		}
+		clave, err := leerClaveJSON[K](token.(string))
+		if err != nil {
+			return err
+		}
+		var dato V
+		if err := decodificador.Decode(&dato); err != nil {
+			return err
+		}
+		dict.Guardar(clave, dato)
+	}
+	_, err = decodificador.Token()
+	return err
+}
+
+// ########################################## CLAVES ########################################################
+
+var tipoTextMarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+var tipoTextUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// escribirClaveJSON y leerClaveJSON siguen el mismo orden que encoding/json con las claves de los map: al escribir,
+// una clave de tipo string se usa tal cual aunque implemente encoding.TextMarshaler, pero al leer se prefiere
+// encoding.TextUnmarshaler
+func escribirClaveJSON[K comparable](clave K) (string, error) {
+	valor := reflect.ValueOf(&clave).Elem()
+	if valor.Kind() == reflect.String {
+		return valor.String(), nil
+	}
+	if valor.Type().Implements(tipoTextMarshaler) {
+		texto, err := valor.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(texto), err
+	}
+	switch valor.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(valor.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(valor.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("%w: %v", ErrClaveJSONNoSoportada, valor.Type())
+}
+
+func leerClaveJSON[K comparable](texto string) (K, error) {
+	var clave K
+	valor := reflect.ValueOf(&clave).Elem()
+	if reflect.PointerTo(valor.Type()).Implements(tipoTextUnmarshaler) {
+		err := valor.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(texto))
+		return clave, err
+	}
+	if valor.Kind() == reflect.String {
+		valor.SetString(texto)
+		return clave, nil
+	}
+	switch valor.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		numero, err := strconv.ParseInt(texto, 10, valor.Type().Bits())
+		if err != nil {
+			return clave, fmt.Errorf("%w: clave %q: %w", ErrFormatoInvalido, texto, err)
+		}
+		valor.SetInt(numero)
+		return clave, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		numero, err := strconv.ParseUint(texto, 10, valor.Type().Bits())
+		if err != nil {
+			return clave, fmt.Errorf("%w: clave %q: %w", ErrFormatoInvalido, texto, err)
+		}
+		valor.SetUint(numero)
+		return clave, nil
+	}
+	return clave, fmt.Errorf("%w: %v", ErrClaveJSONNoSoportada, valor.Type())
+}
+
+// ########################################## OBJETO ########################################################
+
+type parJSON struct {
+	clave string
+	dato  []byte
+}
+
+func escribirParJSON(salida *bufio.Writer, primero bool, clave string, dato []byte) {
+	if !primero {
+		salida.WriteByte(',')
+	}
+	nombre, _ := json.Marshal(clave)
+	salida.Write(nombre)
+	salida.WriteByte(':')
+	salida.Write(dato)
+}
+
+// escribirObjetoJSON escribe los pares que recorre iterar. Para ordenarlos tiene que codificarlos todos antes de
+// escribir el primero
+func escribirObjetoJSON[K comparable, V any](salida *bufio.Writer, iterar func(func(K, V) bool), ordenado bool) error {
+	var pares []parJSON
+	var err error
+	primero := true
+	salida.WriteByte('{')
+	iterar(func(clave K, dato V) bool {
+		var nombre string
+		var valor []byte
+		if nombre, err = escribirClaveJSON(clave); err != nil {
+			return false
+		}
+		if valor, err = json.Marshal(dato); err != nil {
+			return false
+		}
+		if ordenado {
+			pares = append(pares, parJSON{nombre, valor})
+		} else {
+			escribirParJSON(salida, primero, nombre, valor)
+			primero = false
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(pares, func(a, b parJSON) int { return strings.Compare(a.clave, b.clave) })
+	for i, par := range pares {
+		escribirParJSON(salida, i == 0, par.clave, par.dato)
+	}
+	return salida.WriteByte('}')
+}
+
+func serializarJSON[K comparable, V any](iterar func(func(K, V) bool), ordenado bool) ([]byte, error) {
+	var buffer bytes.Buffer
+	salida := bufio.NewWriter(&buffer)
+	if err := escribirObjetoJSON(salida, iterar, ordenado); err != nil {
+		return nil, err
+	}
+	if err := salida.Flush(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// ################################## IMPLEMENTACIONES ######################################################
+
+func (dict *dictImplementacion[K, V]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(dict.Iterar, dict.jsonOrdenado)
+}
+
+func (dict *dictImplementacion[K, V]) UnmarshalJSON(datos []byte) error {
+	return DecodificarJSON[K, V](bytes.NewReader(datos), dict)
+}
+
+func (dict *dictSwiss[K, V]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(dict.Iterar, dict.jsonOrdenado)
+}
+
+func (dict *dictSwiss[K, V]) UnmarshalJSON(datos []byte) error {
+	return DecodificarJSON[K, V](bytes.NewReader(datos), dict)
+}
+
+func (dict *dictConcurrente[K, V]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(dict.Iterar, dict.jsonOrdenado)
+}
+
+func (dict *dictConcurrente[K, V]) UnmarshalJSON(datos []byte) error {
+	return DecodificarJSON[K, V](bytes.NewReader(datos), dict)
+}
+
+// MarshalJSON escribe los pares en el orden del diccionario, salvo que se haya creado con JSONOrdenado
+func (dict *dictPorInsercion[K, V]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(dict.Iterar, dict.jsonOrdenado)
+}
+
+// UnmarshalJSON agrega las claves nuevas en el orden en que aparecen en el objeto
+func (dict *dictPorInsercion[K, V]) UnmarshalJSON(datos []byte) error {
+	return DecodificarJSON[K, V](bytes.NewReader(datos), dict)
+}
+
+// MarshalJSON escribe los pares en el orden del árbol
+func (arbol *abb[K, V]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(arbol.Iterar, false)
+}
+
+func (arbol *abb[K, V]) UnmarshalJSON(datos []byte) error {
+	return DecodificarJSON[K, V](bytes.NewReader(datos), arbol)
+}
+
+// MarshalJSON escribe los pares desde el usado hace más tiempo hasta el más reciente, sin contarlo como un uso
+func (cache *cacheLRU[K, V]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(cache.Iterar, false)
+}
+
+// UnmarshalJSON guarda los pares en el orden del objeto, así cargar lo que escribió MarshalJSON conserva el orden
+// de uso. Si el objeto tiene más pares que la capacidad, se desalojan los primeros
+func (cache *cacheLRU[K, V]) UnmarshalJSON(datos []byte) error {
+	return DecodificarJSON[K, V](bytes.NewReader(datos), cache)
+}
+
+// MarshalJSON escribe los pares que no vencieron, sin sus vencimientos
+func (dict *dictConExpiracion[K, V]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(dict.Iterar, false)
+}
+
+// UnmarshalJSON guarda los pares con Guardar, es decir con el TTL por defecto
+func (dict *dictConExpiracion[K, V]) UnmarshalJSON(datos []byte) error {
+	return DecodificarJSON[K, V](bytes.NewReader(datos), dict)
+}
+
+func (dict *dictBidireccional[K, V]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(dict.Iterar, false)
+}
+
+// UnmarshalJSON guarda los pares con Guardar, así un dato repetido se trata según la política del diccionario
+func (dict *dictBidireccional[K, V]) UnmarshalJSON(datos []byte) error {
+	return DecodificarJSON[K, V](bytes.NewReader(datos), dict)
+}
+
+func (dict *dictPersistente[K, V]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(dict.Iterar, false)
+}
+
+// UnmarshalJSON guarda los pares con Guardar, así cada uno queda anotado en el archivo
+func (dict *dictPersistente[K, V]) UnmarshalJSON(datos []byte) error {
+	return DecodificarJSON[K, V](bytes.NewReader(datos), dict)
+}
+
+// MarshalJSON escribe cada clave con el arreglo de sus datos, en el orden en que se guardaron
+func (multi *multiDiccionario[K, V]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(func(visitar func(K, []V) bool) {
+		multi.listas.Iterar(func(clave K, lista *[]V) bool { return visitar(clave, *lista) })
+	}, false)
+}
+
+// UnmarshalJSON lee un objeto con un arreglo de datos por clave, y los agrega a los que ya tenía cada clave
+func (multi *multiDiccionario[K, V]) UnmarshalJSON(datos []byte) error {
+	listas := CrearHash[K, []V]()
+	if err := DecodificarJSON[K, []V](bytes.NewReader(datos), listas); err != nil {
+		return err
+	}
+	for clave, lista := range listas.All() {
+		for _, dato := range lista {
+			multi.Guardar(clave, dato)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON escribe cada clave con su cuenta
+func (contador *contadorImplementacion[K]) MarshalJSON() ([]byte, error) {
+	return serializarJSON(contador.Iterar, false)
+}
+
+// UnmarshalJSON suma las cuentas del objeto a las que ya tenía el contador, como Incrementar
+func (contador *contadorImplementacion[K]) UnmarshalJSON(datos []byte) error {
+	cuentas := CrearHash[K, int]()
+	if err := DecodificarJSON[K, int](bytes.NewReader(datos), cuentas); err != nil {
+		return err
+	}
+	for clave, cuenta := range cuentas.All() {
+		contador.Incrementar(clave, cuenta)
+	}
+	return nil
+}
+
+// MarshalJSON escribe el conjunto como un arreglo con sus elementos. Como los elementos no son claves de un objeto,
+// pueden ser de cualquier tipo que encoding/json sepa codificar
+func (conjunto *conjuntoImplementacion[K]) MarshalJSON() ([]byte, error) {
+	elementos := make([]K, 0, conjunto.Cantidad())
+	return json.Marshal(slices.AppendSeq(elementos, conjunto.Elementos()))
+}
+
+// UnmarshalJSON agrega los elementos del arreglo a los que ya tenía el conjunto
+func (conjunto *conjuntoImplementacion[K]) UnmarshalJSON(datos []byte) error {
+	var elementos []K
+	if err := json.Unmarshal(datos, &elementos); err != nil {
+		return err
+	}
+	for _, elemento := range elementos {
+		conjunto.Agregar(elemento)
+	}
+	return nil
+}
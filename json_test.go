@@ -0,0 +1,288 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"diccionario/cerrado"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/netip"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestJSONIdaYVuelta(t *testing.T) {
+	t.Log("json.Marshal escribe el diccionario como un objeto, y json.Unmarshal lo vuelve a cargar con los mismos pares")
+	for _, implementacion := range IMPLEMENTACIONES {
+		implementacionActual = implementacion
+		t.Run(implementacion, func(t *testing.T) {
+			dic := crearHash[string, []int]()
+			for i := 0; i < 100; i++ {
+				dic.Guardar(fmt.Sprintf("clave \"%d\"", i), []int{i, -i})
+			}
+			datos, err := json.Marshal(dic)
+			require.NoError(t, err)
+
+			var mapa map[string][]int
+			require.NoError(t, json.Unmarshal(datos, &mapa))
+			require.Len(t, mapa, 100)
+			require.EqualValues(t, []int{7, -7}, mapa["clave \"7\""])
+
+			copia := crearHash[string, []int]()
+			require.NoError(t, json.Unmarshal(datos, copia))
+			require.EqualValues(t, dic.Cantidad(), copia.Cantidad())
+			for clave, dato := range dic.All() {
+				require.EqualValues(t, dato, copia.Obtener(clave))
+			}
+		})
+	}
+}
+
+func TestJSONOtrosDiccionarios(t *testing.T) {
+	t.Log("El diccionario bidireccional, el persistente y el Contador se escriben como un objeto con sus pares, el " +
+		"MultiDiccionario con un arreglo de datos por clave y el Conjunto como un arreglo, y todos se vuelven a " +
+		"cargar con json.Unmarshal")
+	bidireccional := TDADiccionario.CrearHashBidireccional[string, int]()
+	bidireccional.Guardar("uno", 1)
+	bidireccional.Guardar("dos", 2)
+	datos, err := json.Marshal(bidireccional)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"uno": 1, "dos": 2}`, string(datos))
+	copiaBidireccional := TDADiccionario.CrearHashBidireccional[string, int]()
+	require.NoError(t, json.Unmarshal(datos, copiaBidireccional))
+	require.EqualValues(t, "dos", copiaBidireccional.ObtenerClave(2))
+
+	persistente, err := TDADiccionario.AbrirHashPersistente[string, int](filepath.Join(t.TempDir(), "estado.wal"))
+	require.NoError(t, err)
+	defer persistente.Cerrar()
+	require.NoError(t, json.Unmarshal(datos, persistente))
+	datos, err = json.Marshal(persistente)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"uno": 1, "dos": 2}`, string(datos))
+
+	multi := TDADiccionario.CrearMultiDiccionario[string, int]()
+	multi.Guardar("pares", 2)
+	multi.Guardar("pares", 4)
+	multi.Guardar("impares", 1)
+	datos, err = json.Marshal(multi)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"pares": [2, 4], "impares": [1]}`, string(datos))
+	copiaMulti := TDADiccionario.CrearMultiDiccionario[string, int]()
+	copiaMulti.Guardar("pares", 0)
+	require.NoError(t, json.Unmarshal(datos, copiaMulti))
+	require.EqualValues(t, []int{0, 2, 4}, copiaMulti.ObtenerTodos("pares"))
+	require.EqualValues(t, []int{1}, copiaMulti.ObtenerTodos("impares"))
+	require.EqualValues(t, 4, copiaMulti.CantidadPares())
+
+	contador := TDADiccionario.CrearContadorDe(slices.Values([]string{"a", "b", "a"}))
+	datos, err = json.Marshal(contador)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a": 2, "b": 1}`, string(datos))
+	require.NoError(t, json.Unmarshal(datos, contador))
+	require.EqualValues(t, 4, contador.Contar("a"))
+
+	conjunto := TDADiccionario.CrearConjunto[punto]()
+	datos, err = json.Marshal(conjunto)
+	require.NoError(t, err)
+	require.JSONEq(t, `[]`, string(datos))
+	conjunto.Agregar(punto{1, 2, "a"})
+	datos, err = json.Marshal(conjunto)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"X": 1, "Y": 2, "Etiqueta": "a"}]`, string(datos))
+	copiaConjunto := TDADiccionario.CrearConjunto[punto]()
+	require.NoError(t, json.Unmarshal(datos, copiaConjunto))
+	require.True(t, copiaConjunto.Igual(conjunto))
+}
+
+func TestJSONCacheConservaElOrdenDeUso(t *testing.T) {
+	t.Log("La cache se escribe desde el elemento usado hace más tiempo, y al cargarla se conserva el orden de uso")
+	cache := TDADiccionario.CrearCacheLRU[string, int](3)
+	cache.Guardar("a", 1)
+	cache.Guardar("b", 2)
+	cache.Guardar("c", 3)
+	cache.Obtener("a")
+	datos, err := json.Marshal(cache)
+	require.NoError(t, err)
+	require.EqualValues(t, `{"b":2,"c":3,"a":1}`, string(datos))
+
+	copia := TDADiccionario.CrearCacheLRU[string, int](3)
+	require.NoError(t, json.Unmarshal(datos, copia))
+	copia.Guardar("d", 4)
+	require.False(t, copia.Pertenece("b"))
+	require.True(t, copia.Pertenece("a"))
+}
+
+func TestJSONClavesEnteras(t *testing.T) {
+	t.Log("Las claves enteras se escriben en decimal, y al leerlas se rechazan las que no entran en el tipo")
+	type id int8
+	dic := TDADiccionario.CrearHashCon(TDADiccionario.Opciones[id, bool]{JSONOrdenado: true})
+	dic.Guardar(-128, true)
+	dic.Guardar(127, false)
+	datos, err := json.Marshal(dic)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"-128": true, "127": false}`, string(datos))
+
+	copia := TDADiccionario.CrearHash[id, bool]()
+	require.NoError(t, json.Unmarshal(datos, copia))
+	require.True(t, copia.Obtener(-128))
+	require.False(t, copia.Obtener(127))
+
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"128": true}`), copia), TDADiccionario.ErrFormatoInvalido)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"uno": true}`), copia), TDADiccionario.ErrFormatoInvalido)
+	sinSigno := TDADiccionario.CrearHash[uint, bool]()
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"-1": true}`), sinSigno), TDADiccionario.ErrFormatoInvalido)
+}
+
+func TestJSONClavesTextMarshaler(t *testing.T) {
+	t.Log("Las claves que implementan encoding.TextMarshaler se escriben con MarshalText y se leen con UnmarshalText")
+	dic := TDADiccionario.CrearHash[netip.Addr, string]()
+	dic.Guardar(netip.MustParseAddr("10.0.0.1"), "router")
+	dic.Guardar(netip.MustParseAddr("::1"), "local")
+	datos, err := json.Marshal(dic)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"10.0.0.1": "router", "::1": "local"}`, string(datos))
+
+	copia := TDADiccionario.CrearHash[netip.Addr, string]()
+	require.NoError(t, json.Unmarshal(datos, copia))
+	require.EqualValues(t, "router", copia.Obtener(netip.MustParseAddr("10.0.0.1")))
+	require.EqualValues(t, "local", copia.Obtener(netip.MustParseAddr("::1")))
+	require.Error(t, json.Unmarshal([]byte(`{"no es una ip": ""}`), copia))
+}
+
+// claveMayusculas es un string que al leerse de texto se pasa a mayúsculas
+type claveMayusculas string
+
+func (clave *claveMayusculas) UnmarshalText(texto []byte) error {
+	*clave = claveMayusculas(strings.ToUpper(string(texto)))
+	return nil
+}
+
+func TestJSONClavesStringConTextUnmarshaler(t *testing.T) {
+	t.Log("Como encoding/json, una clave de tipo string con UnmarshalText propio se lee con UnmarshalText")
+	datos := []byte(`{"hola": 1, "Mundo": 2}`)
+	var mapa map[claveMayusculas]int
+	require.NoError(t, json.Unmarshal(datos, &mapa))
+
+	dic := TDADiccionario.CrearHash[claveMayusculas, int]()
+	require.NoError(t, json.Unmarshal(datos, dic))
+	require.EqualValues(t, len(mapa), dic.Cantidad())
+	for clave, dato := range mapa {
+		require.EqualValues(t, dato, dic.Obtener(clave))
+	}
+	require.EqualValues(t, 1, dic.Obtener("HOLA"))
+	require.False(t, dic.Pertenece("hola"))
+}
+
+func TestJSONClavesNoSoportadas(t *testing.T) {
+	t.Log("Las claves que no son strings, enteros ni TextMarshaler no se pueden escribir ni leer")
+	dic := TDADiccionario.CrearHash[float64, int]()
+	dic.Guardar(1.5, 1)
+	_, err := json.Marshal(dic)
+	require.ErrorIs(t, err, TDADiccionario.ErrClaveJSONNoSoportada)
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"1.5": 1}`), dic), TDADiccionario.ErrClaveJSONNoSoportada)
+}
+
+func TestJSONOrdenado(t *testing.T) {
+	t.Log("Con JSONOrdenado las claves se escriben ordenadas, así el resultado es siempre el mismo")
+	for _, variante := range []TDADiccionario.Variante{TDADiccionario.CUCKOO, TDADiccionario.SWISS_TABLE} {
+		opciones := TDADiccionario.Opciones[string, int]{Variante: variante, JSONOrdenado: true}
+		dic := TDADiccionario.CrearHashCon(opciones)
+		for _, clave := range []string{"d", "b", "a", "c"} {
+			dic.Guardar(clave, int(clave[0]-'a'))
+		}
+		datos, err := json.Marshal(dic)
+		require.NoError(t, err)
+		require.EqualValues(t, `{"a":0,"b":1,"c":2,"d":3}`, string(datos))
+	}
+
+	concurrente := TDADiccionario.CrearHashConcurrenteCon(4, TDADiccionario.Opciones[int, int]{JSONOrdenado: true})
+	for i := 0; i < 12; i++ {
+		concurrente.Guardar(i, i)
+	}
+	datos, err := json.Marshal(concurrente)
+	require.NoError(t, err)
+	require.EqualValues(t, `{"0":0,"1":1,"10":10,"11":11,"2":2,"3":3,"4":4,"5":5,"6":6,"7":7,"8":8,"9":9}`,
+		string(datos))
+}
+
+func TestJSONOrdenPropio(t *testing.T) {
+	t.Log("Sin JSONOrdenado, el diccionario por inserción escribe y lee los pares en su orden, y el ABB en el del árbol")
+	porInsercion := TDADiccionario.CrearHashPorInsercion[string, int]()
+	require.NoError(t, json.Unmarshal([]byte(`{"z": 1, "a": 2, "m": 3}`), porInsercion))
+	datos, err := json.Marshal(porInsercion)
+	require.NoError(t, err)
+	require.EqualValues(t, `{"z":1,"a":2,"m":3}`, string(datos))
+
+	arbol := TDADiccionario.CrearABB[int, int](func(a, b int) int { return a - b })
+	for _, clave := range []int{10, 2, 33} {
+		arbol.Guardar(clave, clave)
+	}
+	datos, err = json.Marshal(arbol)
+	require.NoError(t, err)
+	require.EqualValues(t, `{"2":2,"10":10,"33":33}`, string(datos))
+}
+
+func TestJSONUnmarshalConservaClaves(t *testing.T) {
+	t.Log("Como con los mapas, json.Unmarshal reemplaza los datos de las claves del objeto y conserva las demás, y " +
+		"un null no cambia nada")
+	dic := TDADiccionario.CrearHash[string, int]()
+	dic.Guardar("a", 1)
+	dic.Guardar("b", 2)
+	require.NoError(t, json.Unmarshal([]byte(`{"b": 20, "c": 30}`), dic))
+	require.NoError(t, json.Unmarshal([]byte(`null`), dic))
+	require.EqualValues(t, 3, dic.Cantidad())
+	require.EqualValues(t, 1, dic.Obtener("a"))
+	require.EqualValues(t, 20, dic.Obtener("b"))
+	require.EqualValues(t, 30, dic.Obtener("c"))
+
+	require.Error(t, json.Unmarshal([]byte(`[1, 2]`), dic))
+	require.Error(t, json.Unmarshal([]byte(`{"d": "no es un entero"}`), dic))
+	require.False(t, dic.Pertenece("d"))
+}
+
+// diccionarioEspiado registra en qué momento se guarda cada clave
+type diccionarioEspiado struct {
+	TDADiccionario.Diccionario[int, string]
+	alGuardar func(int)
+}
+
+func (dic diccionarioEspiado) Guardar(clave int, dato string) {
+	dic.alGuardar(clave)
+	dic.Diccionario.Guardar(clave, dato)
+}
+
+func TestDecodificarJSON(t *testing.T) {
+	t.Log("DecodificarJSON guarda cada par apenas lo lee, sin esperar a tener todo el objeto")
+	lector, escritor := io.Pipe()
+	guardadas := make(chan int)
+	dic := diccionarioEspiado{TDADiccionario.CrearHash[int, string](), func(clave int) { guardadas <- clave }}
+	errores := make(chan error)
+	go func() { errores <- TDADiccionario.DecodificarJSON[int, string](lector, dic) }()
+
+	io.WriteString(escritor, `{"1": "uno", `)
+	require.EqualValues(t, 1, <-guardadas)
+	io.WriteString(escritor, `"2": "dos" `)
+	require.EqualValues(t, 2, <-guardadas)
+	io.WriteString(escritor, `}`)
+	escritor.Close()
+	require.NoError(t, <-errores)
+	require.EqualValues(t, "dos", dic.Obtener(2))
+}
+
+func TestCodificarJSON(t *testing.T) {
+	t.Log("CodificarJSON escribe cualquier Diccionario, incluso los que no implementan json.Marshaler")
+	dic := cerrado.CrearHashRobinHood[string, int]()
+	dic.Guardar("b", 2)
+	dic.Guardar("a", 1)
+	var salida strings.Builder
+	require.NoError(t, TDADiccionario.CodificarJSON(&salida, dic, true))
+	require.EqualValues(t, `{"a":1,"b":2}`, salida.String())
+
+	vacio := cerrado.CrearHashRobinHood[string, int]()
+	salida.Reset()
+	require.NoError(t, TDADiccionario.CodificarJSON(&salida, vacio, false))
+	require.EqualValues(t, `{}`, salida.String())
+}
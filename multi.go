@@ -0,0 +1,175 @@
+package diccionario
+
+import (
+	"iter"
+	"slices"
+)
+
+/* El MultiDiccionario guarda, en un Diccionario de hash, la lista de datos de cada clave. Las claves sin datos se
+borran del Diccionario, así la cantidad de claves es la de este.
+*/
+
+type multiDiccionario[K comparable, V any] struct {
+	listas  Diccionario[K, *[]V]
+	pares   int
+	eventos eventosHash[K, V]
+
+	// modificaciones cuenta los datos agregados y borrados, para que el iterador detecte cambios como en
+	// dictImplementacion
+	modificaciones int
+}
+
+type iteradorMulti[K comparable, V any] struct {
+	diccionario    *multiDiccionario[K, V]
+	claves         IterDiccionario[K, *[]V]
+	posicion       int
+	modificaciones int
+}
+
+func CrearMultiDiccionario[K comparable, V any]() MultiDiccionario[K, V] {
+	return CrearMultiDiccionarioCon(Opciones[K, V]{})
+}
+
+// CrearMultiDiccionarioCon crea un MultiDiccionario cuyo Diccionario de claves usa el hasher y la variante de las
+// opciones. Como Guardar agrega un dato sin reemplazar ninguno, AlGuardar recibe siempre el valor cero como dato
+// anterior, y si la clave ya tenía otros datos. AlBorrar se llama con cada dato borrado, y AlRedimensionar informa
+// la capacidad del Diccionario de claves
+func CrearMultiDiccionarioCon[K comparable, V any](opciones Opciones[K, V]) MultiDiccionario[K, V] {
+	multi := new(multiDiccionario[K, V])
+	multi.eventos = crearEventos(opciones)
+	multi.listas = CrearHashCon(Opciones[K, *[]V]{
+		Hasher:             opciones.Hasher,
+		Variante:           opciones.Variante,
+		MaxDesplazamientos: opciones.MaxDesplazamientos,
+		CapacidadStash:     opciones.CapacidadStash,
+		AlRedimensionar:    multi.eventos.anotarRedimension(),
+	})
+	return multi
+}
+
+func (multi *multiDiccionario[K, V]) Guardar(clave K, dato V) {
+	lista, existia := multi.listas.ObtenerOk(clave)
+	if existia {
+		*lista = append(*lista, dato)
+	} else {
+		multi.listas.Guardar(clave, &[]V{dato})
+	}
+	multi.pares++
+	multi.modificaciones++
+	var cero V
+	multi.eventos.redimensionPendiente()
+	multi.eventos.guardado(clave, cero, dato, existia)
+}
+
+func (multi *multiDiccionario[K, V]) Pertenece(clave K) bool {
+	return multi.listas.Pertenece(clave)
+}
+
+func (multi *multiDiccionario[K, V]) ObtenerTodos(clave K) []V {
+	lista, ok := multi.listas.ObtenerOk(clave)
+	if !ok {
+		return []V{}
+	}
+	return slices.Clone(*lista)
+}
+
+func (multi *multiDiccionario[K, V]) BorrarValor(clave K, dato V, iguales func(V, V) bool) bool {
+	lista, ok := multi.listas.ObtenerOk(clave)
+	if !ok {
+		return false
+	}
+	i := slices.IndexFunc(*lista, func(otro V) bool { return iguales(otro, dato) })
+	if i == -1 {
+		return false
+	}
+	borrado := (*lista)[i]
+	*lista = slices.Delete(*lista, i, i+1)
+	if len(*lista) == 0 {
+		multi.listas.Borrar(clave)
+	}
+	multi.pares--
+	multi.modificaciones++
+	multi.eventos.borrado(clave, borrado)
+	multi.eventos.redimensionPendiente()
+	return true
+}
+
+func (multi *multiDiccionario[K, V]) BorrarClave(clave K) []V {
+	lista, ok := multi.listas.BorrarOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	multi.pares -= len(*lista)
+	multi.modificaciones++
+	for _, dato := range *lista {
+		multi.eventos.borrado(clave, dato)
+	}
+	multi.eventos.redimensionPendiente()
+	return *lista
+}
+
+func (multi *multiDiccionario[K, V]) CantidadClaves() int {
+	return multi.listas.Cantidad()
+}
+
+func (multi *multiDiccionario[K, V]) CantidadPares() int {
+	return multi.pares
+}
+
+// Iterar entra en pánico si la función visitar agrega o borra datos del diccionario
+func (multi *multiDiccionario[K, V]) Iterar(visitar func(K, V) bool) {
+	modificaciones := multi.modificaciones
+	multi.listas.Iterar(func(clave K, lista *[]V) bool {
+		for _, dato := range *lista {
+			if !visitar(clave, dato) {
+				return false
+			}
+			if multi.modificaciones != modificaciones {
+				panic(ErrDiccionarioModificado.Error())
+			}
+		}
+		return true
+	})
+}
+
+func (multi *multiDiccionario[K, V]) All() iter.Seq2[K, V] {
+	return multi.Iterar
+}
+
+// ################################### PRIMITIVAS ITERADOR ###################################################
+
+func (multi *multiDiccionario[K, V]) Iterador() IterDiccionario[K, V] {
+	return &iteradorMulti[K, V]{
+		diccionario:    multi,
+		claves:         multi.listas.Iterador(),
+		modificaciones: multi.modificaciones,
+	}
+}
+
+// HaySiguiente, y con ella el resto de las primitivas, entra en pánico si se agregaron o borraron datos desde que se
+// creó el iterador
+func (iter *iteradorMulti[K, V]) HaySiguiente() bool {
+	if iter.modificaciones != iter.diccionario.modificaciones {
+		panic(ErrDiccionarioModificado.Error())
+	}
+	return iter.claves.HaySiguiente()
+}
+
+func (iter *iteradorMulti[K, V]) VerActual() (K, V) {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	clave, lista := iter.claves.VerActual()
+	return clave, (*lista)[iter.posicion]
+}
+
+func (iter *iteradorMulti[K, V]) Siguiente() K {
+	clave, _ := iter.VerActual()
+	_, lista := iter.claves.VerActual()
+	iter.posicion++
+	if iter.posicion == len(*lista) {
+		iter.claves.Siguiente()
+		iter.posicion = 0
+	}
+	return clave
+}
@@ -0,0 +1,110 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func igualesEnteros(a, b int) bool {
+	return a == b
+}
+
+func TestMultiGuardarAgrega(t *testing.T) {
+	t.Log("Guardar agrega datos a la clave en lugar de reemplazarlos, incluso si se repiten, y ObtenerTodos los " +
+		"devuelve en orden sin exponer la lista interna")
+	multi := TDADiccionario.CrearMultiDiccionario[string, int]()
+	require.Empty(t, multi.ObtenerTodos("A"))
+	multi.Guardar("A", 1)
+	multi.Guardar("A", 2)
+	multi.Guardar("B", 3)
+	multi.Guardar("A", 1)
+	require.Equal(t, []int{1, 2, 1}, multi.ObtenerTodos("A"))
+	require.Equal(t, []int{3}, multi.ObtenerTodos("B"))
+	require.EqualValues(t, 2, multi.CantidadClaves())
+	require.EqualValues(t, 4, multi.CantidadPares())
+	datos := multi.ObtenerTodos("A")
+	datos[0] = 100
+	require.Equal(t, []int{1, 2, 1}, multi.ObtenerTodos("A"))
+}
+
+func TestMultiBorrar(t *testing.T) {
+	t.Log("BorrarValor borra un solo dato igual al pedido, y la clave deja de pertenecer al quedarse sin datos. " +
+		"BorrarClave borra todos sus datos y entra en pánico si la clave no pertenece")
+	multi := TDADiccionario.CrearMultiDiccionario[string, int]()
+	multi.Guardar("A", 1)
+	multi.Guardar("A", 2)
+	multi.Guardar("A", 1)
+	multi.Guardar("B", 3)
+	require.True(t, multi.BorrarValor("A", 1, igualesEnteros))
+	require.Equal(t, []int{2, 1}, multi.ObtenerTodos("A"))
+	require.False(t, multi.BorrarValor("A", 5, igualesEnteros))
+	require.False(t, multi.BorrarValor("C", 1, igualesEnteros))
+	require.True(t, multi.BorrarValor("B", 3, igualesEnteros))
+	require.False(t, multi.Pertenece("B"))
+	require.EqualValues(t, 2, multi.CantidadPares())
+
+	require.Equal(t, []int{2, 1}, multi.BorrarClave("A"))
+	require.False(t, multi.Pertenece("A"))
+	require.EqualValues(t, 0, multi.CantidadClaves())
+	require.EqualValues(t, 0, multi.CantidadPares())
+	require.PanicsWithValue(t, "La clave no pertenece al diccionario", func() { multi.BorrarClave("A") })
+}
+
+func TestMultiBorrarValorConIgualdadPropia(t *testing.T) {
+	t.Log("BorrarValor usa la igualdad que recibe, lo que permite borrar datos que no son comparables con ==")
+	multi := TDADiccionario.CrearMultiDiccionario[int, []string]()
+	multi.Guardar(1, []string{"a", "b"})
+	multi.Guardar(1, []string{"c"})
+	mismasPalabras := func(a, b []string) bool { return strings.Join(a, " ") == strings.Join(b, " ") }
+	require.True(t, multi.BorrarValor(1, []string{"a", "b"}, mismasPalabras))
+	require.Equal(t, [][]string{{"c"}}, multi.ObtenerTodos(1))
+}
+
+func TestMultiRecorrerPares(t *testing.T) {
+	t.Log("Iterar, All e Iterador recorren todos los pares, con los datos de cada clave juntos y en orden")
+	multi := TDADiccionario.CrearMultiDiccionario[int, int]()
+	for i := 0; i < 300; i++ {
+		multi.Guardar(i%30, i)
+	}
+	verificar := func(pares [][2]int) {
+		require.Len(t, pares, 300)
+		ultimoDe := make(map[int]int)
+		for i, par := range pares {
+			require.EqualValues(t, par[0], par[1]%30)
+			if ultimo, ok := ultimoDe[par[0]]; ok {
+				require.EqualValues(t, i-1, ultimo, "los datos de una clave deben estar juntos")
+				require.Less(t, pares[ultimo][1], par[1])
+			}
+			ultimoDe[par[0]] = i
+		}
+	}
+
+	var interno, rango, externo [][2]int
+	multi.Iterar(func(clave int, dato int) bool {
+		interno = append(interno, [2]int{clave, dato})
+		return true
+	})
+	for clave, dato := range multi.All() {
+		rango = append(rango, [2]int{clave, dato})
+	}
+	for iter := multi.Iterador(); iter.HaySiguiente(); iter.Siguiente() {
+		clave, dato := iter.VerActual()
+		externo = append(externo, [2]int{clave, dato})
+	}
+	verificar(interno)
+	require.Equal(t, interno, rango)
+	require.Equal(t, interno, externo)
+}
+
+func TestMultiIteradorFallaSiSeModifica(t *testing.T) {
+	t.Log("Agregar un dato, aunque sea a una clave existente, invalida a los iteradores")
+	multi := TDADiccionario.CrearMultiDiccionario[int, int]()
+	multi.Guardar(1, 1)
+	iter := multi.Iterador()
+	multi.Guardar(1, 2)
+	require.PanicsWithValue(t, TDADiccionario.ErrDiccionarioModificado.Error(), func() { iter.VerActual() })
+	vacio := TDADiccionario.CrearMultiDiccionario[int, int]().Iterador()
+	require.PanicsWithValue(t, "El iterador termino de iterar", func() { vacio.Siguiente() })
+}
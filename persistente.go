@@ -0,0 +1,497 @@
+package diccionario
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	FIRMA_PERSISTENCIA   = "TDAW"
+	VERSION_PERSISTENCIA = 2
+
+	// REGISTROS_PARA_COMPACTAR es la cantidad de registros a partir de la cual se compacta el archivo, si además
+	// tiene más del doble de registros que claves
+	REGISTROS_PARA_COMPACTAR = 1024
+
+	INTERVALO_SINCRONIZACION = time.Second
+	LARGO_ENCABEZADO_WAL     = 12
+)
+
+const (
+	REGISTRO_GUARDAR byte = iota + 1
+	REGISTRO_BORRAR
+)
+
+// Sincronizacion elige cuándo se fuerza la escritura en disco de los registros de un DiccionarioPersistente
+type Sincronizacion int
+
+const (
+	// SINCRONIZAR_SIEMPRE sincroniza después de cada Guardar y Borrar. Es la más segura y la más lenta
+	SINCRONIZAR_SIEMPRE Sincronizacion = iota
+
+	// SINCRONIZAR_POR_INTERVALO sincroniza desde una goroutine cada IntervaloSincronizacion, si hubo cambios. Si el
+	// sistema se cae, se pierden a lo sumo los cambios de ese intervalo
+	SINCRONIZAR_POR_INTERVALO
+
+	// SINCRONIZAR_NUNCA deja que el sistema operativo decida cuándo escribir. Sobrevive a que se caiga el programa,
+	// pero no a que se caiga el sistema
+	SINCRONIZAR_NUNCA
+)
+
+// OpcionesPersistencia configura un DiccionarioPersistente. El valor cero es válido
+type OpcionesPersistencia[K comparable, V any] struct {
+
+	// Sincronizacion es la política de sincronización del archivo. Por defecto es SINCRONIZAR_SIEMPRE
+	Sincronizacion Sincronizacion
+
+	// IntervaloSincronizacion es la frecuencia de SINCRONIZAR_POR_INTERVALO. Por defecto es INTERVALO_SINCRONIZACION
+	IntervaloSincronizacion time.Duration
+
+	// RegistrosParaCompactar es la cantidad de registros a partir de la cual se compacta el archivo al guardar o
+	// borrar, si tiene más del doble de registros que claves. Por defecto es REGISTROS_PARA_COMPACTAR, y un valor
+	// negativo deja la compactación solo en manos de Compactar
+	RegistrosParaCompactar int
+
+	// Hasher, CodecClaves y CodecDatos son los del diccionario en memoria, como en Opciones. Los codecs se usan
+	// también para escribir los registros
+	Hasher      Hasher[K]
+	CodecClaves Codec[K]
+	CodecDatos  Codec[V]
+}
+
+/* Formato del archivo, versión 2:
+
+	FIRMA_PERSISTENCIA | VERSION_PERSISTENCIA (1 byte) | registros...
+
+Cada registro es:
+
+	CRC-32 IEEE del contenido (4 bytes) | largo del contenido (4 bytes) | CRC-32 IEEE de los 8 bytes anteriores (4 bytes)
+	contenido
+
+los enteros en little endian, y el contenido es REGISTRO_GUARDAR, la clave y el dato, o REGISTRO_BORRAR y la clave,
+con clave y dato precedidos por su largo como en MarshalBinary. El CRC del encabezado permite confiar en el largo
+antes de leer el contenido.
+
+Al abrir el archivo se aplican sus registros en orden. Si el programa se cayó mientras escribía, el último registro
+puede estar incompleto, y se lo trunca: es el caso de un encabezado cortado, de un largo válido que pasa el final del
+archivo, o de un CRC que no coincide en un registro que termina justo al final. Cualquier otro registro con un CRC que
+no coincide no puede venir de una caída, así que abrir el archivo falla con ErrFormatoInvalido y no lo modifica.
+
+Compactar escribe un archivo nuevo al lado del original, con un REGISTRO_GUARDAR por clave, y lo renombra sobre el
+original, así una caída durante la compactación deja uno de los dos archivos completo.
+*/
+
+type dictPersistente[K comparable, V any] struct {
+	memoria Diccionario[K, V]
+	codecs  codecsDiccionario[K, V]
+	ruta    string
+
+	// candado protege al archivo, que la goroutine de SINCRONIZAR_POR_INTERVALO usa en paralelo con el diccionario
+	candado   sync.Mutex
+	archivo   *os.File
+	pendiente bool
+	err       error
+
+	sincronizacion         Sincronizacion
+	registros              int
+	registrosParaCompactar int
+	buffer                 []byte
+
+	detener       chan struct{}
+	detenerUnaVez sync.Once
+}
+
+func AbrirHashPersistente[K comparable, V any](ruta string) (DiccionarioPersistente[K, V], error) {
+	return AbrirHashPersistenteCon(ruta, OpcionesPersistencia[K, V]{})
+}
+
+// AbrirHashPersistenteCon abre el archivo de la ruta, creándolo si no existe, y carga sus registros en un
+// Diccionario de hash
+func AbrirHashPersistenteCon[K comparable, V any](ruta string, opciones OpcionesPersistencia[K, V]) (DiccionarioPersistente[K, V], error) {
+	dict := new(dictPersistente[K, V])
+	dict.ruta = ruta
+	dict.memoria = CrearHashCon(Opciones[K, V]{
+		Hasher:      opciones.Hasher,
+		CodecClaves: opciones.CodecClaves,
+		CodecDatos:  opciones.CodecDatos,
+	})
+	dict.codecs = crearCodecs(Opciones[K, V]{CodecClaves: opciones.CodecClaves, CodecDatos: opciones.CodecDatos})
+	dict.sincronizacion = opciones.Sincronizacion
+	dict.registrosParaCompactar = opciones.RegistrosParaCompactar
+	if dict.registrosParaCompactar == 0 {
+		dict.registrosParaCompactar = REGISTROS_PARA_COMPACTAR
+	}
+
+	archivo, err := os.OpenFile(ruta, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := dict.cargar(archivo); err != nil {
+		archivo.Close()
+		return nil, fmt.Errorf("%s: %w", ruta, err)
+	}
+	dict.archivo = archivo
+
+	dict.detener = make(chan struct{})
+	if dict.sincronizacion == SINCRONIZAR_POR_INTERVALO {
+		intervalo := opciones.IntervaloSincronizacion
+		if intervalo <= 0 {
+			intervalo = INTERVALO_SINCRONIZACION
+		}
+		go dict.sincronizarPeriodicamente(intervalo)
+	}
+	return dict, nil
+}
+
+func (dict *dictPersistente[K, V]) sincronizarPeriodicamente(intervalo time.Duration) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			dict.Sincronizar()
+		case <-dict.detener:
+			return
+		}
+	}
+}
+
+// ######################################### CARGA ##########################################################
+
+// cargar aplica los registros del archivo, trunca el registro incompleto del final si lo hay, y deja el archivo
+// posicionado al final para seguir escribiendo. Solo se trunca un registro que llega hasta el final del archivo: si
+// uno del medio está corrupto se devuelve ErrFormatoInvalido sin tocar el archivo, para no perder los que le siguen
+func (dict *dictPersistente[K, V]) cargar(archivo *os.File) error {
+	info, err := archivo.Stat()
+	if err != nil {
+		return err
+	}
+	tamanio := info.Size()
+	encabezado := append([]byte(FIRMA_PERSISTENCIA), VERSION_PERSISTENCIA)
+	if tamanio == 0 {
+		if _, err := archivo.Write(encabezado); err != nil {
+			return err
+		}
+		return archivo.Sync()
+	}
+
+	lector := bufio.NewReader(archivo)
+	leido := make([]byte, len(encabezado))
+	if _, err := io.ReadFull(lector, leido); err != nil || string(leido[:len(FIRMA_PERSISTENCIA)]) != FIRMA_PERSISTENCIA {
+		return ErrFormatoInvalido
+	}
+	if version := leido[len(FIRMA_PERSISTENCIA)]; version != VERSION_PERSISTENCIA {
+		return fmt.Errorf("%w: %d", ErrVersionNoSoportada, version)
+	}
+
+	posicion := int64(len(encabezado))
+	var cabecera [LARGO_ENCABEZADO_WAL]byte
+	var contenido []byte
+	for posicion < tamanio {
+		if _, err := io.ReadFull(lector, cabecera[:]); err != nil {
+			break
+		}
+		resto := tamanio - posicion - LARGO_ENCABEZADO_WAL
+		if crc32.ChecksumIEEE(cabecera[:8]) != binary.LittleEndian.Uint32(cabecera[8:]) {
+			if resto == 0 {
+				break
+			}
+			return fmt.Errorf("%w: encabezado del registro en la posición %d", ErrFormatoInvalido, posicion)
+		}
+		crc := binary.LittleEndian.Uint32(cabecera[:4])
+		largo := int64(binary.LittleEndian.Uint32(cabecera[4:8]))
+		if largo > resto {
+			break
+		}
+		contenido = slices.Grow(contenido[:0], int(largo))[:largo]
+		if _, err := io.ReadFull(lector, contenido); err != nil {
+			return err
+		}
+		if crc32.ChecksumIEEE(contenido) != crc {
+			if largo == resto {
+				break
+			}
+			return fmt.Errorf("%w: CRC del registro en la posición %d", ErrFormatoInvalido, posicion)
+		}
+		if err := dict.aplicar(contenido); err != nil {
+			return fmt.Errorf("registro en la posición %d: %w", posicion, err)
+		}
+		posicion += LARGO_ENCABEZADO_WAL + largo
+		dict.registros++
+	}
+
+	if posicion < tamanio {
+		if err := archivo.Truncate(posicion); err != nil {
+			return err
+		}
+		if err := archivo.Sync(); err != nil {
+			return err
+		}
+	}
+	_, err = archivo.Seek(posicion, io.SeekStart)
+	return err
+}
+
+func (dict *dictPersistente[K, V]) aplicar(contenido []byte) error {
+	if len(contenido) == 0 {
+		return ErrFormatoInvalido
+	}
+	tipo, resto := contenido[0], contenido[1:]
+	bytesClave, resto, err := leerConLargo(resto)
+	if err != nil {
+		return err
+	}
+	clave, err := dict.codecs.claves.Decodificar(bytesClave)
+	if err != nil {
+		return err
+	}
+	switch tipo {
+	case REGISTRO_GUARDAR:
+		bytesDato, resto, err := leerConLargo(resto)
+		if err != nil || len(resto) != 0 {
+			return ErrFormatoInvalido
+		}
+		dato, err := dict.codecs.datos.Decodificar(bytesDato)
+		if err != nil {
+			return err
+		}
+		dict.memoria.Guardar(clave, dato)
+	case REGISTRO_BORRAR:
+		if len(resto) != 0 {
+			return ErrFormatoInvalido
+		}
+		dict.memoria.BorrarOk(clave)
+	default:
+		return ErrFormatoInvalido
+	}
+	return nil
+}
+
+// ######################################## ESCRITURA #######################################################
+
+// registro agrega a destino el registro de guardar o borrar la clave, con su encabezado
+func (dict *dictPersistente[K, V]) registro(destino []byte, tipo byte, clave K, dato V) ([]byte, error) {
+	inicio := len(destino)
+	destino = append(destino, make([]byte, LARGO_ENCABEZADO_WAL)...)
+	destino = append(destino, tipo)
+	var err error
+	destino, dict.buffer, err = codificarConLargo(destino, dict.codecs.claves, clave, dict.buffer)
+	if err == nil && tipo == REGISTRO_GUARDAR {
+		destino, dict.buffer, err = codificarConLargo(destino, dict.codecs.datos, dato, dict.buffer)
+	}
+	if err != nil {
+		return destino[:inicio], err
+	}
+	contenido := destino[inicio+LARGO_ENCABEZADO_WAL:]
+	encabezado := destino[inicio : inicio+LARGO_ENCABEZADO_WAL]
+	binary.LittleEndian.PutUint32(encabezado, crc32.ChecksumIEEE(contenido))
+	binary.LittleEndian.PutUint32(encabezado[4:], uint32(len(contenido)))
+	binary.LittleEndian.PutUint32(encabezado[8:], crc32.ChecksumIEEE(encabezado[:8]))
+	return destino, nil
+}
+
+// anotar escribe un registro en el archivo y lo sincroniza según la política. Después de un error no escribe más
+func (dict *dictPersistente[K, V]) anotar(tipo byte, clave K, dato V) {
+	dict.candado.Lock()
+	defer dict.candado.Unlock()
+	if dict.err != nil {
+		return
+	}
+	registro, err := dict.registro(nil, tipo, clave, dato)
+	if err == nil {
+		_, err = dict.archivo.Write(registro)
+	}
+	if err == nil && dict.sincronizacion == SINCRONIZAR_SIEMPRE {
+		err = dict.archivo.Sync()
+	}
+	if err != nil {
+		dict.err = err
+		return
+	}
+	dict.pendiente = dict.sincronizacion != SINCRONIZAR_SIEMPRE
+	dict.registros++
+}
+
+func (dict *dictPersistente[K, V]) hayQueCompactar() bool {
+	return dict.registrosParaCompactar > 0 && dict.registros >= dict.registrosParaCompactar &&
+		dict.registros > 2*dict.memoria.Cantidad()
+}
+
+func (dict *dictPersistente[K, V]) Compactar() error {
+	dict.candado.Lock()
+	defer dict.candado.Unlock()
+	if dict.err != nil {
+		return dict.err
+	}
+	if err := dict.compactar(); err != nil {
+		dict.err = err
+	}
+	return dict.err
+}
+
+// compactar se llama con el candado tomado
+func (dict *dictPersistente[K, V]) compactar() error {
+	temporal := dict.ruta + ".compactando"
+	archivo, err := os.OpenFile(temporal, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if archivo != nil {
+			archivo.Close()
+			os.Remove(temporal)
+		}
+	}()
+
+	salida := bufio.NewWriter(archivo)
+	salida.WriteString(FIRMA_PERSISTENCIA)
+	salida.WriteByte(VERSION_PERSISTENCIA)
+	var registro []byte
+	dict.memoria.Iterar(func(clave K, dato V) bool {
+		if registro, err = dict.registro(registro[:0], REGISTRO_GUARDAR, clave, dato); err != nil {
+			return false
+		}
+		_, err = salida.Write(registro)
+		return err == nil
+	})
+	if err == nil {
+		err = salida.Flush()
+	}
+	if err == nil {
+		err = archivo.Sync()
+	}
+	if err == nil {
+		err = os.Rename(temporal, dict.ruta)
+	}
+	if err != nil {
+		return err
+	}
+
+	// El archivo renombrado queda abierto y posicionado al final, listo para seguir escribiendo
+	dict.archivo.Close()
+	dict.archivo, archivo = archivo, nil
+	dict.registros = dict.memoria.Cantidad()
+	dict.pendiente = false
+	return sincronizarDirectorio(filepath.Dir(dict.ruta))
+}
+
+// sincronizarDirectorio hace persistente el renombre de un archivo del directorio
+func sincronizarDirectorio(ruta string) error {
+	directorio, err := os.Open(ruta)
+	if err != nil {
+		return err
+	}
+	defer directorio.Close()
+	if err := directorio.Sync(); err != nil && !errors.Is(err, os.ErrInvalid) {
+		return err
+	}
+	return nil
+}
+
+func (dict *dictPersistente[K, V]) Sincronizar() error {
+	dict.candado.Lock()
+	defer dict.candado.Unlock()
+	if dict.err != nil || !dict.pendiente {
+		return dict.err
+	}
+	if err := dict.archivo.Sync(); err != nil {
+		dict.err = err
+		return err
+	}
+	dict.pendiente = false
+	return nil
+}
+
+func (dict *dictPersistente[K, V]) Err() error {
+	dict.candado.Lock()
+	defer dict.candado.Unlock()
+	return dict.err
+}
+
+func (dict *dictPersistente[K, V]) Cerrar() error {
+	dict.detenerUnaVez.Do(func() { close(dict.detener) })
+	err := dict.Sincronizar()
+	dict.candado.Lock()
+	defer dict.candado.Unlock()
+	if errCerrar := dict.archivo.Close(); err == nil && !errors.Is(errCerrar, os.ErrClosed) {
+		err = errCerrar
+	}
+	return err
+}
+
+// ################################### PRIMITIVAS DICCIONARIO #################################################
+
+func (dict *dictPersistente[K, V]) Guardar(clave K, dato V) {
+	dict.memoria.Guardar(clave, dato)
+	dict.anotar(REGISTRO_GUARDAR, clave, dato)
+	if dict.hayQueCompactar() {
+		dict.Compactar()
+	}
+}
+
+func (dict *dictPersistente[K, V]) Pertenece(clave K) bool {
+	return dict.memoria.Pertenece(clave)
+}
+
+func (dict *dictPersistente[K, V]) Obtener(clave K) V {
+	return dict.memoria.Obtener(clave)
+}
+
+func (dict *dictPersistente[K, V]) ObtenerOk(clave K) (V, bool) {
+	return dict.memoria.ObtenerOk(clave)
+}
+
+func (dict *dictPersistente[K, V]) Borrar(clave K) V {
+	dato, ok := dict.BorrarOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+// BorrarOk solo anota el borrado si la clave pertenecía
+func (dict *dictPersistente[K, V]) BorrarOk(clave K) (V, bool) {
+	dato, ok := dict.memoria.BorrarOk(clave)
+	if ok {
+		var cero V
+		dict.anotar(REGISTRO_BORRAR, clave, cero)
+		if dict.hayQueCompactar() {
+			dict.Compactar()
+		}
+	}
+	return dato, ok
+}
+
+func (dict *dictPersistente[K, V]) Cantidad() int {
+	return dict.memoria.Cantidad()
+}
+
+func (dict *dictPersistente[K, V]) Iterar(visitar func(K, V) bool) {
+	dict.memoria.Iterar(visitar)
+}
+
+func (dict *dictPersistente[K, V]) All() iter.Seq2[K, V] {
+	return dict.memoria.All()
+}
+
+func (dict *dictPersistente[K, V]) Claves() iter.Seq[K] {
+	return dict.memoria.Claves()
+}
+
+func (dict *dictPersistente[K, V]) Valores() iter.Seq[V] {
+	return dict.memoria.Valores()
+}
+
+func (dict *dictPersistente[K, V]) Iterador() IterDiccionario[K, V] {
+	return dict.memoria.Iterador()
+}
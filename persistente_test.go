@@ -0,0 +1,234 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func abrirPersistente(t *testing.T, ruta string, opciones TDADiccionario.OpcionesPersistencia[string, int]) TDADiccionario.DiccionarioPersistente[string, int] {
+	dic, err := TDADiccionario.AbrirHashPersistenteCon(ruta, opciones)
+	require.NoError(t, err)
+	return dic
+}
+
+func tamanioArchivo(t *testing.T, ruta string) int64 {
+	info, err := os.Stat(ruta)
+	require.NoError(t, err)
+	return info.Size()
+}
+
+func TestPersistenteSobreviveReinicio(t *testing.T) {
+	t.Log("Al volver a abrir el archivo, el diccionario tiene lo que se guardó y no lo que se borró, con cada " +
+		"política de sincronización")
+	politicas := map[string]TDADiccionario.Sincronizacion{
+		"Siempre":      TDADiccionario.SINCRONIZAR_SIEMPRE,
+		"PorIntervalo": TDADiccionario.SINCRONIZAR_POR_INTERVALO,
+		"Nunca":        TDADiccionario.SINCRONIZAR_NUNCA,
+	}
+	for nombre, politica := range politicas {
+		t.Run(nombre, func(t *testing.T) {
+			ruta := filepath.Join(t.TempDir(), "estado.wal")
+			opciones := TDADiccionario.OpcionesPersistencia[string, int]{
+				Sincronizacion:          politica,
+				IntervaloSincronizacion: time.Millisecond,
+			}
+			dic := abrirPersistente(t, ruta, opciones)
+			for i := 0; i < 100; i++ {
+				dic.Guardar(fmt.Sprint(i), i)
+			}
+			for i := 0; i < 100; i += 2 {
+				require.EqualValues(t, i, dic.Borrar(fmt.Sprint(i)))
+			}
+			dic.Guardar("1", -1)
+			_, ok := dic.BorrarOk("no está")
+			require.False(t, ok)
+			require.NoError(t, dic.Cerrar())
+
+			dic = abrirPersistente(t, ruta, opciones)
+			defer dic.Cerrar()
+			require.EqualValues(t, 50, dic.Cantidad())
+			require.EqualValues(t, -1, dic.Obtener("1"))
+			require.False(t, dic.Pertenece("2"))
+			require.EqualValues(t, 99, dic.Obtener("99"))
+		})
+	}
+}
+
+func TestPersistenteSincronizaPorIntervalo(t *testing.T) {
+	t.Log("Con SINCRONIZAR_POR_INTERVALO, la goroutine de sincronización convive con Guardar sin errores")
+	ruta := filepath.Join(t.TempDir(), "estado.wal")
+	dic := abrirPersistente(t, ruta, TDADiccionario.OpcionesPersistencia[string, int]{
+		Sincronizacion:          TDADiccionario.SINCRONIZAR_POR_INTERVALO,
+		IntervaloSincronizacion: time.Microsecond,
+		RegistrosParaCompactar:  64,
+	})
+	for i := 0; i < 2000; i++ {
+		dic.Guardar(fmt.Sprint(i%10), i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, dic.Err())
+	require.NoError(t, dic.Cerrar())
+}
+
+func TestPersistenteTruncaFinalCortado(t *testing.T) {
+	t.Log("Si el último registro quedó cortado o con un CRC que no coincide, al abrir se lo descarta y se trunca el " +
+		"archivo, y se puede seguir escribiendo a continuación")
+	cortes := map[string]func(datos []byte) []byte{
+		"Cortado":     func(datos []byte) []byte { return datos[:len(datos)-3] },
+		"CRCInvalido": func(datos []byte) []byte { datos[len(datos)-1] ^= 0xFF; return datos },
+		"Basura":      func(datos []byte) []byte { return append(datos, 0x01, 0x02) },
+	}
+	for nombre, cortar := range cortes {
+		t.Run(nombre, func(t *testing.T) {
+			ruta := filepath.Join(t.TempDir(), "estado.wal")
+			opciones := TDADiccionario.OpcionesPersistencia[string, int]{}
+			dic := abrirPersistente(t, ruta, opciones)
+			dic.Guardar("a", 1)
+			dic.Guardar("b", 2)
+			tamanioConDos := tamanioArchivo(t, ruta)
+			dic.Guardar("c", 3)
+			require.NoError(t, dic.Cerrar())
+
+			datos, err := os.ReadFile(ruta)
+			require.NoError(t, err)
+			datos = cortar(datos)
+			require.NoError(t, os.WriteFile(ruta, datos, 0o644))
+
+			dic = abrirPersistente(t, ruta, opciones)
+			if nombre == "Basura" {
+				require.True(t, dic.Pertenece("c"))
+			} else {
+				require.False(t, dic.Pertenece("c"))
+				require.EqualValues(t, tamanioConDos, tamanioArchivo(t, ruta))
+			}
+			require.EqualValues(t, 2, dic.Obtener("b"))
+			dic.Guardar("d", 4)
+			require.NoError(t, dic.Cerrar())
+
+			dic = abrirPersistente(t, ruta, opciones)
+			defer dic.Cerrar()
+			require.EqualValues(t, 1, dic.Obtener("a"))
+			require.EqualValues(t, 4, dic.Obtener("d"))
+		})
+	}
+}
+
+func TestPersistenteCorrupcionEnElMedio(t *testing.T) {
+	t.Log("Si un registro del medio tiene un CRC que no coincide, en el contenido o en el largo, no puede venir de una " +
+		"caída: abrir el archivo falla con ErrFormatoInvalido y el archivo queda intacto, sin perder los registros " +
+		"que le siguen")
+	corrupciones := map[string]func(datos []byte, registro int64){
+		"Contenido": func(datos []byte, registro int64) { datos[registro+TDADiccionario.LARGO_ENCABEZADO_WAL] ^= 0xFF },
+		// El largo pasa a apuntar más allá del final del archivo, como si fuera el último registro cortado
+		"Largo": func(datos []byte, registro int64) { datos[registro+7] = 0x7F },
+	}
+	for nombre, corromper := range corrupciones {
+		t.Run(nombre, func(t *testing.T) {
+			ruta := filepath.Join(t.TempDir(), "estado.wal")
+			opciones := TDADiccionario.OpcionesPersistencia[string, int]{}
+			dic := abrirPersistente(t, ruta, opciones)
+			dic.Guardar("a", 1)
+			tamanioConUno := tamanioArchivo(t, ruta)
+			dic.Guardar("b", 2)
+			dic.Guardar("c", 3)
+			require.NoError(t, dic.Cerrar())
+
+			datos, err := os.ReadFile(ruta)
+			require.NoError(t, err)
+			corromper(datos, tamanioConUno)
+			require.NoError(t, os.WriteFile(ruta, datos, 0o644))
+
+			_, err = TDADiccionario.AbrirHashPersistenteCon(ruta, opciones)
+			require.ErrorIs(t, err, TDADiccionario.ErrFormatoInvalido)
+			guardados, err := os.ReadFile(ruta)
+			require.NoError(t, err)
+			require.Equal(t, datos, guardados)
+		})
+	}
+}
+
+func TestPersistenteCompacta(t *testing.T) {
+	t.Log("Reemplazar muchas veces las mismas claves compacta el archivo solo, y Compactar deja un registro por " +
+		"clave sin perder datos")
+	directorio := t.TempDir()
+	ruta := filepath.Join(directorio, "estado.wal")
+	opciones := TDADiccionario.OpcionesPersistencia[string, int]{
+		Sincronizacion:         TDADiccionario.SINCRONIZAR_NUNCA,
+		RegistrosParaCompactar: 100,
+	}
+	dic := abrirPersistente(t, ruta, opciones)
+	for i := 0; i < 10000; i++ {
+		dic.Guardar(fmt.Sprint(i%10), i)
+	}
+	// Sin compactar serían 10000 registros
+	require.Less(t, tamanioArchivo(t, ruta), int64(100*20))
+
+	dic.Borrar("0")
+	require.NoError(t, dic.Compactar())
+	compactado := tamanioArchivo(t, ruta)
+	require.NoError(t, dic.Compactar())
+	require.EqualValues(t, compactado, tamanioArchivo(t, ruta))
+	dic.Guardar("nueva", 1)
+	require.NoError(t, dic.Cerrar())
+
+	entradas, err := os.ReadDir(directorio)
+	require.NoError(t, err)
+	require.Len(t, entradas, 1)
+
+	dic = abrirPersistente(t, ruta, opciones)
+	defer dic.Cerrar()
+	require.EqualValues(t, 10, dic.Cantidad())
+	require.False(t, dic.Pertenece("0"))
+	require.EqualValues(t, 9999, dic.Obtener("9"))
+	require.EqualValues(t, 1, dic.Obtener("nueva"))
+}
+
+func TestPersistenteSinCompactacionAutomatica(t *testing.T) {
+	t.Log("Con RegistrosParaCompactar negativo, el archivo solo se compacta al llamar a Compactar")
+	ruta := filepath.Join(t.TempDir(), "estado.wal")
+	dic := abrirPersistente(t, ruta, TDADiccionario.OpcionesPersistencia[string, int]{
+		Sincronizacion:         TDADiccionario.SINCRONIZAR_NUNCA,
+		RegistrosParaCompactar: -1,
+	})
+	defer dic.Cerrar()
+	for i := 0; i < 5000; i++ {
+		dic.Guardar("clave", i)
+	}
+	sinCompactar := tamanioArchivo(t, ruta)
+	require.Greater(t, sinCompactar, int64(5000*10))
+	require.NoError(t, dic.Compactar())
+	require.Less(t, tamanioArchivo(t, ruta), int64(100))
+}
+
+func TestPersistenteArchivoInvalido(t *testing.T) {
+	t.Log("Abrir un archivo que no es de un diccionario persistente, o de otra versión, devuelve un error")
+	directorio := t.TempDir()
+	otro := filepath.Join(directorio, "otro")
+	require.NoError(t, os.WriteFile(otro, []byte("no es un diccionario"), 0o644))
+	_, err := TDADiccionario.AbrirHashPersistente[string, int](otro)
+	require.ErrorIs(t, err, TDADiccionario.ErrFormatoInvalido)
+
+	otraVersion := filepath.Join(directorio, "otra version")
+	encabezado := append([]byte(TDADiccionario.FIRMA_PERSISTENCIA), TDADiccionario.VERSION_PERSISTENCIA+1)
+	require.NoError(t, os.WriteFile(otraVersion, encabezado, 0o644))
+	_, err = TDADiccionario.AbrirHashPersistente[string, int](otraVersion)
+	require.ErrorIs(t, err, TDADiccionario.ErrVersionNoSoportada)
+}
+
+func TestPersistenteErrorDeEscritura(t *testing.T) {
+	t.Log("Después de cerrar el diccionario, Guardar sigue cambiando la memoria pero Err informa que no se anotó")
+	ruta := filepath.Join(t.TempDir(), "estado.wal")
+	dic := abrirPersistente(t, ruta, TDADiccionario.OpcionesPersistencia[string, int]{})
+	dic.Guardar("a", 1)
+	require.NoError(t, dic.Cerrar())
+	require.NoError(t, dic.Err())
+	dic.Guardar("b", 2)
+	require.True(t, dic.Pertenece("b"))
+	require.ErrorIs(t, dic.Err(), os.ErrClosed)
+	require.ErrorIs(t, dic.Compactar(), os.ErrClosed)
+}
@@ -0,0 +1,248 @@
+package diccionario
+
+import "iter"
+
+// Orden elige cómo se ordenan los elementos de un DiccionarioPorInsercion
+type Orden int
+
+const (
+	// ORDEN_INSERCION recorre los elementos en el orden en que se guardaron sus claves por primera vez. Reemplazar
+	// el dato de una clave no cambia su lugar
+	ORDEN_INSERCION Orden = iota
+
+	// ORDEN_ACCESO recorre los elementos desde el usado hace más tiempo hasta el usado más recientemente. Guardar,
+	// Obtener y ObtenerOk mueven la clave al final; Pertenece no
+	ORDEN_ACCESO
+)
+
+/* El diccionario por inserción guarda sus elementos en una lista doblemente enlazada, en el orden del recorrido, y
+usa un Diccionario de hash para ir de cada clave a su nodo de la lista. Así puede borrar, o mover al final,
+cualquier elemento en O(1).
+*/
+
+type dictPorInsercion[K comparable, V any] struct {
+	indice  Diccionario[K, *nodoPorInsercion[K, V]]
+	primero *nodoPorInsercion[K, V]
+	ultimo  *nodoPorInsercion[K, V]
+	orden   Orden
+
+	jsonOrdenado bool
+	eventos      eventosHash[K, V]
+	codecs       codecsDiccionario[K, V]
+
+	// modificaciones cuenta los elementos agregados, borrados y, con ORDEN_ACCESO, movidos al final
+	modificaciones int
+}
+
+type nodoPorInsercion[K comparable, V any] struct {
+	clave     K
+	dato      V
+	anterior  *nodoPorInsercion[K, V]
+	siguiente *nodoPorInsercion[K, V]
+}
+
+type iteradorPorInsercion[K comparable, V any] struct {
+	diccionario    *dictPorInsercion[K, V]
+	actual         *nodoPorInsercion[K, V]
+	modificaciones int
+}
+
+func CrearHashPorInsercion[K comparable, V any]() DiccionarioPorInsercion[K, V] {
+	return CrearHashPorInsercionCon(ORDEN_INSERCION, Opciones[K, V]{})
+}
+
+// CrearHashPorInsercionCon crea un DiccionarioPorInsercion con el orden indicado. Las opciones se usan para crear el
+// Diccionario de hash que indexa los elementos, salvo JSONOrdenado, los codecs y los eventos, que se aplican al
+// diccionario por inserción. AlRedimensionar informa la capacidad del índice
+func CrearHashPorInsercionCon[K comparable, V any](orden Orden, opciones Opciones[K, V]) DiccionarioPorInsercion[K, V] {
+	dict := new(dictPorInsercion[K, V])
+	dict.orden = orden
+	dict.jsonOrdenado = opciones.JSONOrdenado
+	dict.eventos = crearEventos(opciones)
+	dict.codecs = crearCodecs(opciones)
+	dict.indice = CrearHashCon(Opciones[K, *nodoPorInsercion[K, V]]{
+		Hasher:             opciones.Hasher,
+		Variante:           opciones.Variante,
+		MaxDesplazamientos: opciones.MaxDesplazamientos,
+		CapacidadStash:     opciones.CapacidadStash,
+		AlRedimensionar:    dict.eventos.anotarRedimension(),
+	})
+	return dict
+}
+
+// ###################################### LISTA DE ELEMENTOS ##################################################
+
+func (dict *dictPorInsercion[K, V]) enlazarAlFinal(nodo *nodoPorInsercion[K, V]) {
+	nodo.anterior, nodo.siguiente = dict.ultimo, nil
+	if dict.ultimo == nil {
+		dict.primero = nodo
+	} else {
+		dict.ultimo.siguiente = nodo
+	}
+	dict.ultimo = nodo
+}
+
+func (dict *dictPorInsercion[K, V]) desenlazar(nodo *nodoPorInsercion[K, V]) {
+	if nodo.anterior == nil {
+		dict.primero = nodo.siguiente
+	} else {
+		nodo.anterior.siguiente = nodo.siguiente
+	}
+	if nodo.siguiente == nil {
+		dict.ultimo = nodo.anterior
+	} else {
+		nodo.siguiente.anterior = nodo.anterior
+	}
+	nodo.anterior, nodo.siguiente = nil, nil
+}
+
+// accedido mueve el nodo al final de la lista si el diccionario está ordenado por acceso
+func (dict *dictPorInsercion[K, V]) accedido(nodo *nodoPorInsercion[K, V]) {
+	if dict.orden != ORDEN_ACCESO || nodo == dict.ultimo {
+		return
+	}
+	dict.desenlazar(nodo)
+	dict.enlazarAlFinal(nodo)
+	dict.modificaciones++
+}
+
+// ################################### PRIMITIVAS DICCIONARIO #################################################
+
+func (dict *dictPorInsercion[K, V]) Guardar(clave K, dato V) {
+	if nodo, ok := dict.indice.ObtenerOk(clave); ok {
+		viejo := nodo.dato
+		nodo.dato = dato
+		dict.accedido(nodo)
+		dict.eventos.guardado(clave, viejo, dato, true)
+		return
+	}
+	nodo := &nodoPorInsercion[K, V]{clave: clave, dato: dato}
+	dict.indice.Guardar(clave, nodo)
+	dict.enlazarAlFinal(nodo)
+	dict.modificaciones++
+	var cero V
+	dict.eventos.redimensionPendiente()
+	dict.eventos.guardado(clave, cero, dato, false)
+}
+
+func (dict *dictPorInsercion[K, V]) Pertenece(clave K) bool {
+	return dict.indice.Pertenece(clave)
+}
+
+func (dict *dictPorInsercion[K, V]) Obtener(clave K) V {
+	dato, ok := dict.ObtenerOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (dict *dictPorInsercion[K, V]) ObtenerOk(clave K) (V, bool) {
+	nodo, ok := dict.indice.ObtenerOk(clave)
+	if !ok {
+		var cero V
+		return cero, false
+	}
+	dict.accedido(nodo)
+	return nodo.dato, true
+}
+
+func (dict *dictPorInsercion[K, V]) Borrar(clave K) V {
+	dato, ok := dict.BorrarOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (dict *dictPorInsercion[K, V]) BorrarOk(clave K) (V, bool) {
+	nodo, ok := dict.indice.BorrarOk(clave)
+	if !ok {
+		var cero V
+		return cero, false
+	}
+	dict.desenlazar(nodo)
+	dict.modificaciones++
+	dict.eventos.borrado(clave, nodo.dato)
+	dict.eventos.redimensionPendiente()
+	return nodo.dato, true
+}
+
+func (dict *dictPorInsercion[K, V]) Cantidad() int {
+	return dict.indice.Cantidad()
+}
+
+func (dict *dictPorInsercion[K, V]) Primero() (K, V) {
+	if dict.primero == nil {
+		panic(ErrDiccionarioVacio.Error())
+	}
+	return dict.primero.clave, dict.primero.dato
+}
+
+func (dict *dictPorInsercion[K, V]) Ultimo() (K, V) {
+	if dict.ultimo == nil {
+		panic(ErrDiccionarioVacio.Error())
+	}
+	return dict.ultimo.clave, dict.ultimo.dato
+}
+
+// Iterar entra en pánico si la función visitar agrega o borra claves del diccionario, o si lo reordena accediendo
+// a una clave con ORDEN_ACCESO
+func (dict *dictPorInsercion[K, V]) Iterar(visitar func(K, V) bool) {
+	modificaciones := dict.modificaciones
+	for nodo := dict.primero; nodo != nil; nodo = nodo.siguiente {
+		if !visitar(nodo.clave, nodo.dato) {
+			return
+		}
+		if dict.modificaciones != modificaciones {
+			panic(ErrDiccionarioModificado.Error())
+		}
+	}
+}
+
+func (dict *dictPorInsercion[K, V]) All() iter.Seq2[K, V] {
+	return dict.Iterar
+}
+
+func (dict *dictPorInsercion[K, V]) Claves() iter.Seq[K] {
+	return func(visitar func(K) bool) {
+		dict.Iterar(func(clave K, _ V) bool { return visitar(clave) })
+	}
+}
+
+func (dict *dictPorInsercion[K, V]) Valores() iter.Seq[V] {
+	return func(visitar func(V) bool) {
+		dict.Iterar(func(_ K, dato V) bool { return visitar(dato) })
+	}
+}
+
+// ################################### PRIMITIVAS ITERADOR ###################################################
+
+func (dict *dictPorInsercion[K, V]) Iterador() IterDiccionario[K, V] {
+	return &iteradorPorInsercion[K, V]{diccionario: dict, actual: dict.primero, modificaciones: dict.modificaciones}
+}
+
+// HaySiguiente, y con ella el resto de las primitivas, entra en pánico si el diccionario cambió desde que se creó
+// el iterador
+func (iter *iteradorPorInsercion[K, V]) HaySiguiente() bool {
+	if iter.modificaciones != iter.diccionario.modificaciones {
+		panic(ErrDiccionarioModificado.Error())
+	}
+	return iter.actual != nil
+}
+
+func (iter *iteradorPorInsercion[K, V]) VerActual() (K, V) {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	return iter.actual.clave, iter.actual.dato
+}
+
+func (iter *iteradorPorInsercion[K, V]) Siguiente() K {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	clave := iter.actual.clave
+	iter.actual = iter.actual.siguiente
+	return clave
+}
@@ -0,0 +1,91 @@
+package diccionario_test
+
+import (
+	TDADiccionario "diccionario"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"testing"
+)
+
+func TestPorInsercionRecorreEnOrdenDeInsercion(t *testing.T) {
+	t.Log("El diccionario por inserción recorre las claves en el orden en que se guardaron, aunque se reemplacen datos " +
+		"y aunque la tabla de hash se redimensione")
+	dic := TDADiccionario.CrearHashPorInsercion[int, int]()
+	esperadas := make([]int, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		clave := (i * 7919) % 1000
+		dic.Guardar(clave, i)
+		esperadas = append(esperadas, clave)
+	}
+	dic.Guardar(esperadas[0], -1)
+	require.Equal(t, esperadas, slices.Collect(dic.Claves()))
+	var recorridas []int
+	for iter := dic.Iterador(); iter.HaySiguiente(); iter.Siguiente() {
+		clave, _ := iter.VerActual()
+		recorridas = append(recorridas, clave)
+	}
+	require.Equal(t, esperadas, recorridas)
+	primera, dato := dic.Primero()
+	require.EqualValues(t, esperadas[0], primera)
+	require.EqualValues(t, -1, dato)
+	ultima, _ := dic.Ultimo()
+	require.EqualValues(t, esperadas[999], ultima)
+}
+
+func TestPorInsercionBorrarMantieneElOrden(t *testing.T) {
+	t.Log("Borrar claves del principio, del medio y del final no altera el orden del resto, y una clave borrada " +
+		"que se vuelve a guardar pasa al final")
+	dic := TDADiccionario.CrearHashPorInsercion[string, int]()
+	for i, clave := range []string{"A", "B", "C", "D", "E"} {
+		dic.Guardar(clave, i)
+	}
+	require.EqualValues(t, 0, dic.Borrar("A"))
+	require.EqualValues(t, 2, dic.Borrar("C"))
+	require.EqualValues(t, 4, dic.Borrar("E"))
+	require.Equal(t, []string{"B", "D"}, slices.Collect(dic.Claves()))
+	dic.Guardar("A", 5)
+	require.Equal(t, []string{"B", "D", "A"}, slices.Collect(dic.Claves()))
+	require.Equal(t, []int{1, 3, 5}, slices.Collect(dic.Valores()))
+	dic.Borrar("B")
+	dic.Borrar("D")
+	dic.Borrar("A")
+	require.PanicsWithValue(t, "El diccionario esta vacio", func() { dic.Primero() })
+	require.PanicsWithValue(t, "El diccionario esta vacio", func() { dic.Ultimo() })
+}
+
+func TestPorInsercionPorAcceso(t *testing.T) {
+	t.Log("Con ORDEN_ACCESO, Guardar, Obtener y ObtenerOk mueven la clave al final del recorrido, y Pertenece no")
+	dic := TDADiccionario.CrearHashPorInsercionCon(TDADiccionario.ORDEN_ACCESO, TDADiccionario.Opciones[string, int]{})
+	for i, clave := range []string{"A", "B", "C", "D"} {
+		dic.Guardar(clave, i)
+	}
+	dic.Obtener("A")
+	require.Equal(t, []string{"B", "C", "D", "A"}, slices.Collect(dic.Claves()))
+	dic.ObtenerOk("C")
+	dic.Guardar("B", 10)
+	require.Equal(t, []string{"D", "A", "C", "B"}, slices.Collect(dic.Claves()))
+	require.True(t, dic.Pertenece("D"))
+	primera, _ := dic.Primero()
+	require.EqualValues(t, "D", primera)
+	dic.ObtenerOk("Z")
+	require.Equal(t, []string{"D", "A", "C", "B"}, slices.Collect(dic.Claves()))
+}
+
+func TestPorInsercionPorAccesoInvalidaIteradores(t *testing.T) {
+	t.Log("Con ORDEN_ACCESO, acceder a una clave la cambia de lugar, por lo que invalida a los iteradores")
+	dic := TDADiccionario.CrearHashPorInsercionCon(TDADiccionario.ORDEN_ACCESO, TDADiccionario.Opciones[int, int]{})
+	for i := 0; i < 10; i++ {
+		dic.Guardar(i, i)
+	}
+	iter := dic.Iterador()
+	dic.Obtener(9)
+	require.True(t, iter.HaySiguiente())
+	dic.Obtener(0)
+	require.PanicsWithValue(t, TDADiccionario.ErrDiccionarioModificado.Error(), func() { iter.HaySiguiente() })
+	require.PanicsWithValue(t, TDADiccionario.ErrDiccionarioModificado.Error(), func() {
+		dic.Iterar(func(clave int, _ int) bool {
+			dic.Obtener(clave)
+			return true
+		})
+	})
+}
@@ -0,0 +1,357 @@
+package diccionario
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+)
+
+const (
+	CELDAS_POR_GRUPO   = 8
+	GRUPOS_INICIALES   = 16
+	MAX_FC_SWISS       = 7.0 / 8.0
+	MIN_FC_SWISS       = 1.0 / 16.0
+	CONTROL_VACIO      = 0x80
+	CONTROL_BORRADO    = 0xFE
+	MASCARA_FRAGMENTO  = 0x7F
+	BYTES_MENOS_SIGNIF = 0x0101010101010101
+	BYTES_MAS_SIGNIF   = 0x8080808080808080
+)
+
+/* Tabla estilo SwissTable (flat_hash_map de Abseil). Las celdas se agrupan de a CELDAS_POR_GRUPO, y cada grupo tiene
+una palabra de control con un byte por celda: CONTROL_VACIO, CONTROL_BORRADO, o los 7 bits bajos del hash de la
+clave guardada (el fragmento). Para buscar, se compara el fragmento contra los 8 bytes de control a la vez con
+operaciones de bits sobre un uint64 (SWAR), y solo se comparan las claves de las celdas que coinciden. Claves y
+valores se guardan dentro del propio grupo, sin punteros.
+*/
+
+type dictSwiss[K comparable, V any] struct {
+	grupos    []grupoSwiss[K, V]
+	elementos int
+	borrados  int
+	hasher    Hasher[K]
+	semilla   maphash.Seed
+
+	// modificaciones cuenta las claves agregadas y borradas y las redimensiones, como en dictImplementacion
+	modificaciones int
+
+	// contadores acumula lo que informa Estadisticas, como en dictImplementacion
+	contadores contadoresHash
+
+	eventos      eventosHash[K, V]
+	codecs       codecsDiccionario[K, V]
+	jsonOrdenado bool
+}
+
+type grupoSwiss[K comparable, V any] struct {
+	control uint64
+	claves  [CELDAS_POR_GRUPO]K
+	valores [CELDAS_POR_GRUPO]V
+}
+
+type iteradorSwiss[K comparable, V any] struct {
+	diccionario    *dictSwiss[K, V]
+	posicion       int
+	modificaciones int
+}
+
+func crearDictSwiss[K comparable, V any](opciones Opciones[K, V]) *dictSwiss[K, V] {
+	dict := &dictSwiss[K, V]{hasher: opciones.Hasher, semilla: maphash.MakeSeed()}
+	if dict.hasher == nil {
+		dict.hasher = HasherPorDefecto[K]()
+	}
+	dict.grupos = crearGrupos[K, V](GRUPOS_INICIALES)
+	dict.eventos = crearEventos(opciones)
+	dict.codecs = crearCodecs(opciones)
+	dict.jsonOrdenado = opciones.JSONOrdenado
+	return dict
+}
+
+func crearGrupos[K comparable, V any](cantidad int) []grupoSwiss[K, V] {
+	grupos := make([]grupoSwiss[K, V], cantidad)
+	for i := range grupos {
+		grupos[i].control = BYTES_MENOS_SIGNIF * CONTROL_VACIO
+	}
+	return grupos
+}
+
+// ###################################### PALABRA DE CONTROL ##################################################
+
+// coincidencias devuelve una máscara con el bit más significativo encendido en cada byte de control igual al
+// fragmento. Puede marcar de más algún byte que esté por encima de una coincidencia real, por lo que siempre hay que
+// comparar la clave
+func coincidencias(control uint64, fragmento uint8) uint64 {
+	x := control ^ (BYTES_MENOS_SIGNIF * uint64(fragmento))
+	return (x - BYTES_MENOS_SIGNIF) &^ x & BYTES_MAS_SIGNIF
+}
+
+func vacias(control uint64) uint64 {
+	return control &^ (control << 6) & BYTES_MAS_SIGNIF
+}
+
+func vaciasOBorradas(control uint64) uint64 {
+	return control & BYTES_MAS_SIGNIF
+}
+
+// primeraCelda devuelve la celda del primer byte marcado en la máscara
+func primeraCelda(mascara uint64) int {
+	return bits.TrailingZeros64(mascara) / 8
+}
+
+func (grupo *grupoSwiss[K, V]) marcar(celda int, control uint8) {
+	desplazamiento := uint(celda * 8)
+	grupo.control = grupo.control&^(0xFF<<desplazamiento) | uint64(control)<<desplazamiento
+}
+
+func (grupo *grupoSwiss[K, V]) ocupada(celda int) bool {
+	return grupo.control>>(celda*8)&CONTROL_VACIO == 0
+}
+
+// ###################################### BÚSQUEDA Y GUARDADO #################################################
+
+// hashear separa el hash en la posición del primer grupo a probar y el fragmento que va a la palabra de control
+func (dict *dictSwiss[K, V]) hashear(clave K) (int, uint8) {
+	hash := dict.hasher.Hashear(clave, PRIMER_HASH, dict.semilla)
+	return int(hash>>7) & (len(dict.grupos) - 1), uint8(hash & MASCARA_FRAGMENTO)
+}
+
+// buscar devuelve el grupo y la celda de la clave, y si pertenece. Los grupos se prueban con sondeo cuadrático, y
+// la búsqueda termina en el primer grupo con alguna celda vacía
+func (dict *dictSwiss[K, V]) buscar(clave K) (int, int, bool) {
+	mascara := len(dict.grupos) - 1
+	posicion, fragmento := dict.hashear(clave)
+	for salto := 1; ; salto++ {
+		grupo := &dict.grupos[posicion]
+		for candidatas := coincidencias(grupo.control, fragmento); candidatas != 0; candidatas &= candidatas - 1 {
+			celda := primeraCelda(candidatas)
+			if grupo.claves[celda] == clave {
+				return posicion, celda, true
+			}
+		}
+		if vacias(grupo.control) != 0 {
+			return 0, 0, false
+		}
+		posicion = (posicion + salto) & mascara
+	}
+}
+
+// lugarLibre devuelve el primer grupo y celda, vacía o borrada, de la secuencia de sondeo de la clave
+func (dict *dictSwiss[K, V]) lugarLibre(clave K) (int, int, uint8) {
+	mascara := len(dict.grupos) - 1
+	posicion, fragmento := dict.hashear(clave)
+	for salto := 1; ; salto++ {
+		if libres := vaciasOBorradas(dict.grupos[posicion].control); libres != 0 {
+			return posicion, primeraCelda(libres), fragmento
+		}
+		posicion = (posicion + salto) & mascara
+	}
+}
+
+func (dict *dictSwiss[K, V]) ubicar(clave K, dato V) {
+	posicion, celda, fragmento := dict.lugarLibre(clave)
+	grupo := &dict.grupos[posicion]
+	if grupo.control>>(celda*8)&0xFF == CONTROL_BORRADO {
+		dict.borrados--
+	}
+	grupo.marcar(celda, fragmento)
+	grupo.claves[celda] = clave
+	grupo.valores[celda] = dato
+}
+
+// ######################################### REDIMENSION ###################################################
+
+func (dict *dictSwiss[K, V]) capacidad() int {
+	return len(dict.grupos) * CELDAS_POR_GRUPO
+}
+
+func (dict *dictSwiss[K, V]) redimensionar(cantidadGrupos int) {
+	anteriores := dict.grupos
+	if cantidadGrupos == len(anteriores) {
+		dict.contadores.rehasheos++
+	}
+	dict.contadores.redimensionado(len(anteriores), cantidadGrupos)
+	dict.grupos = crearGrupos[K, V](cantidadGrupos)
+	dict.borrados = 0
+	dict.modificaciones++
+	for i := range anteriores {
+		for celda := 0; celda < CELDAS_POR_GRUPO; celda++ {
+			if anteriores[i].ocupada(celda) {
+				dict.ubicar(anteriores[i].claves[celda], anteriores[i].valores[celda])
+			}
+		}
+	}
+}
+
+// ################################### PRIMITIVAS DICCIONARIO #################################################
+
+func (dict *dictSwiss[K, V]) Guardar(clave K, dato V) {
+	if posicion, celda, pertenece := dict.buscar(clave); pertenece {
+		viejo := dict.grupos[posicion].valores[celda]
+		dict.grupos[posicion].valores[celda] = dato
+		dict.eventos.guardado(clave, viejo, dato, true)
+		return
+	}
+
+	// Las celdas borradas alargan las búsquedas igual que las ocupadas. Si son muchas, alcanza con reubicar todo en
+	// una tabla del mismo tamaño para descartarlas
+	capacidadVieja := dict.capacidad()
+	if float32(dict.elementos+dict.borrados+1) > MAX_FC_SWISS*float32(dict.capacidad()) {
+		grupos := len(dict.grupos)
+		if float32(dict.elementos+1) > MAX_FC_SWISS*float32(dict.capacidad())/FACTOR_REDIMENSION {
+			grupos *= FACTOR_REDIMENSION
+		}
+		dict.redimensionar(grupos)
+	}
+	dict.ubicar(clave, dato)
+	dict.elementos++
+	dict.modificaciones++
+	var cero V
+	dict.eventos.redimensionado(capacidadVieja, dict.capacidad())
+	dict.eventos.guardado(clave, cero, dato, false)
+}
+
+func (dict *dictSwiss[K, V]) Pertenece(clave K) bool {
+	_, _, pertenece := dict.buscar(clave)
+	return pertenece
+}
+
+func (dict *dictSwiss[K, V]) Obtener(clave K) V {
+	dato, ok := dict.ObtenerOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (dict *dictSwiss[K, V]) ObtenerOk(clave K) (V, bool) {
+	posicion, celda, pertenece := dict.buscar(clave)
+	if !pertenece {
+		var cero V
+		return cero, false
+	}
+	return dict.grupos[posicion].valores[celda], true
+}
+
+func (dict *dictSwiss[K, V]) Borrar(clave K) V {
+	dato, ok := dict.BorrarOk(clave)
+	if !ok {
+		panic(ErrClaveNoPertenece.Error())
+	}
+	return dato
+}
+
+func (dict *dictSwiss[K, V]) BorrarOk(clave K) (V, bool) {
+	posicion, celda, pertenece := dict.buscar(clave)
+	if !pertenece {
+		var cero V
+		return cero, false
+	}
+
+	grupo := &dict.grupos[posicion]
+	dato := grupo.valores[celda]
+	var claveCero K
+	var datoCero V
+	grupo.claves[celda], grupo.valores[celda] = claveCero, datoCero
+	// Si el grupo tiene alguna celda vacía ninguna búsqueda pasó de largo por él, así que la celda puede quedar vacía
+	if vacias(grupo.control) != 0 {
+		grupo.marcar(celda, CONTROL_VACIO)
+	} else {
+		grupo.marcar(celda, CONTROL_BORRADO)
+		dict.borrados++
+	}
+	dict.elementos--
+	dict.modificaciones++
+
+	capacidadVieja := dict.capacidad()
+	if float32(dict.elementos) < MIN_FC_SWISS*float32(dict.capacidad()) && len(dict.grupos) > GRUPOS_INICIALES {
+		dict.redimensionar(len(dict.grupos) / FACTOR_REDIMENSION)
+	}
+	capacidadNueva := dict.capacidad()
+	dict.eventos.borrado(clave, dato)
+	dict.eventos.redimensionado(capacidadVieja, capacidadNueva)
+	return dato, true
+}
+
+func (dict *dictSwiss[K, V]) Cantidad() int {
+	return dict.elementos
+}
+
+// Iterar entra en pánico si la función visitar agrega o borra claves del diccionario
+func (dict *dictSwiss[K, V]) Iterar(visitar func(K, V) bool) {
+	modificaciones := dict.modificaciones
+	for i := 0; i < len(dict.grupos); i++ {
+		for celda := 0; celda < CELDAS_POR_GRUPO; celda++ {
+			grupo := &dict.grupos[i]
+			if !grupo.ocupada(celda) {
+				continue
+			}
+			if !visitar(grupo.claves[celda], grupo.valores[celda]) {
+				return
+			}
+			if dict.modificaciones != modificaciones {
+				panic(ErrDiccionarioModificado.Error())
+			}
+		}
+	}
+}
+
+func (dict *dictSwiss[K, V]) All() iter.Seq2[K, V] {
+	return dict.Iterar
+}
+
+func (dict *dictSwiss[K, V]) Claves() iter.Seq[K] {
+	return func(visitar func(K) bool) {
+		dict.Iterar(func(clave K, _ V) bool { return visitar(clave) })
+	}
+}
+
+func (dict *dictSwiss[K, V]) Valores() iter.Seq[V] {
+	return func(visitar func(V) bool) {
+		dict.Iterar(func(_ K, dato V) bool { return visitar(dato) })
+	}
+}
+
+// ################################### PRIMITIVAS ITERADOR ###################################################
+
+func (dict *dictSwiss[K, V]) Iterador() IterDiccionario[K, V] {
+	return &iteradorSwiss[K, V]{
+		diccionario:    dict,
+		posicion:       dict.siguienteOcupada(0),
+		modificaciones: dict.modificaciones,
+	}
+}
+
+// Las posiciones del iterador numeran las celdas de todos los grupos, una a continuación de la otra
+func (dict *dictSwiss[K, V]) siguienteOcupada(desde int) int {
+	for desde < dict.capacidad() && !dict.grupos[desde/CELDAS_POR_GRUPO].ocupada(desde%CELDAS_POR_GRUPO) {
+		desde++
+	}
+	return desde
+}
+
+// HaySiguiente, y con ella el resto de las primitivas, entra en pánico si el diccionario cambió desde que se creó
+// el iterador
+func (iter *iteradorSwiss[K, V]) HaySiguiente() bool {
+	if iter.modificaciones != iter.diccionario.modificaciones {
+		panic(ErrDiccionarioModificado.Error())
+	}
+	return iter.posicion < iter.diccionario.capacidad()
+}
+
+func (iter *iteradorSwiss[K, V]) VerActual() (K, V) {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	grupo := &iter.diccionario.grupos[iter.posicion/CELDAS_POR_GRUPO]
+	celda := iter.posicion % CELDAS_POR_GRUPO
+	return grupo.claves[celda], grupo.valores[celda]
+}
+
+func (iter *iteradorSwiss[K, V]) Siguiente() K {
+	if !iter.HaySiguiente() {
+		panic(ErrIteradorTerminado.Error())
+	}
+	clave, _ := iter.VerActual()
+	iter.posicion = iter.diccionario.siguienteOcupada(iter.posicion + 1)
+	return clave
+}